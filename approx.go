@@ -0,0 +1,226 @@
+package flinx
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ApproxCountDistinct estimates the number of distinct elements of a collection
+// with a HyperLogLog sketch, using 2^precision registers of one byte whatever
+// the size of the collection. precision is clamped between 4 and 18.
+//
+// hash must return equal hashes for equal elements, it is mixed before use, so
+// a simple hash such as FNV is enough. The relative standard error of the
+// estimate is about 1.04/sqrt(2^precision), that is 1.6% for precision 12 and
+// 0.8% for precision 14.
+func ApproxCountDistinct[T any](precision uint8, hash func(T) uint64) func(q Query[T]) uint64 {
+	if precision < 4 {
+		precision = 4
+	} else if precision > 18 {
+		precision = 18
+	}
+
+	return func(q Query[T]) uint64 {
+		m := 1 << precision
+		registers := make([]uint8, m)
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			h := mixHash(hash(item))
+			index := h >> (64 - precision)
+			// The sentinel bit bounds the rank when the remaining bits are zeros.
+			rank := uint8(bits.LeadingZeros64(h<<precision|1<<(precision-1))) + 1
+			if rank > registers[index] {
+				registers[index] = rank
+			}
+		}
+
+		sum, zeros := 0., 0
+		for _, r := range registers {
+			sum += math.Ldexp(1, -int(r))
+			if r == 0 {
+				zeros++
+			}
+		}
+
+		fm := float64(m)
+		estimate := hllAlpha(m) * fm * fm / sum
+		if estimate <= 2.5*fm && zeros > 0 {
+			// Linear counting is more accurate for small cardinalities.
+			estimate = fm * math.Log(fm/float64(zeros))
+		}
+
+		return uint64(estimate + 0.5)
+	}
+
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// mixHash is the finalizer of SplitMix64, it spreads the bits of a hash that
+// may be poorly distributed.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// ApproxQuantiles estimates the given quantiles of the numeric values obtained
+// by invoking selector on each element of a collection, with a t-digest sketch.
+// Quantiles are fractions ranging from 0 to 1, unlike the p parameter of
+// Percentile which is a percentage: quantile 0.9 estimates Percentile(90).
+// Method returns NaN for every quantile if collection contains no elements,
+// and for quantiles out of range or NaN.
+//
+// The sketch keeps at most compression centroids whatever the size of
+// the collection, 100 being a good default. The error is smallest near the
+// extreme quantiles: with compression 100, the rank of the estimated value is
+// typically within 0.5% of the requested quantile around the median, and much
+// closer towards 0 and 1. The minimum and maximum values are exact.
+func ApproxQuantiles[T any, N constraints.Integer | constraints.Float](compression float64, selector func(T) N,
+	quantiles ...float64) func(q Query[T]) []float64 {
+	if compression < 10 {
+		compression = 10
+	}
+
+	return func(q Query[T]) []float64 {
+		d := &tdigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			if x := float64(selector(item)); !math.IsNaN(x) {
+				d.add(x)
+			}
+		}
+		d.compress()
+
+		r := make([]float64, len(quantiles))
+		for i, quantile := range quantiles {
+			r[i] = d.quantile(quantile)
+		}
+		return r
+	}
+
+}
+
+type centroid struct {
+	mean, weight float64
+}
+
+// tdigest is a merging t-digest: values are buffered and periodically merged
+// into centroids, each centroid spanning at most one unit of the scale
+// function, so that there are about compression/2 and never more than
+// compression centroids, the ones near the extreme quantiles being the
+// smallest.
+type tdigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	total       float64
+	min, max    float64
+}
+
+func (d *tdigest) add(x float64) {
+	d.buffer = append(d.buffer, centroid{x, 1})
+	if x < d.min {
+		d.min = x
+	}
+	if x > d.max {
+		d.max = x
+	}
+
+	if len(d.buffer) >= int(5*d.compression) {
+		d.compress()
+	}
+}
+
+func (d *tdigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+
+	for _, c := range d.buffer {
+		d.total += c.weight
+	}
+	all := append(d.buffer, d.centroids...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	current := all[0]
+	before := 0.
+	for _, c := range all[1:] {
+		if d.scale((before+current.weight+c.weight)/d.total)-d.scale(before/d.total) <= 1 {
+			current.weight += c.weight
+			current.mean += (c.mean - current.mean) * c.weight / current.weight
+			continue
+		}
+
+		merged = append(merged, current)
+		before += current.weight
+		current = c
+	}
+
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// scale is the k1 scale function of the t-digest.
+func (d *tdigest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (d *tdigest) quantile(q float64) float64 {
+	if len(d.centroids) == 0 || q < 0 || q > 1 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q == 0 || len(d.centroids) == 1 && d.centroids[0].weight == 1 {
+		return d.min
+	}
+	if q == 1 {
+		return d.max
+	}
+
+	// Each centroid is centered on the middle of its weight, values between
+	// the centers of two centroids are linearly interpolated, and the extreme
+	// half centroids are interpolated with the exact minimum and maximum.
+	index := q * d.total
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	if index < first.weight/2 {
+		return d.min + (first.mean-d.min)*index/(first.weight/2)
+	}
+	if index > d.total-last.weight/2 {
+		return last.mean + (d.max-last.mean)*(index-d.total+last.weight/2)/(last.weight/2)
+	}
+
+	cumulative := 0.
+	for i := 0; i < len(d.centroids)-1; i++ {
+		c, c2 := d.centroids[i], d.centroids[i+1]
+		center := cumulative + c.weight/2
+		center2 := cumulative + c.weight + c2.weight/2
+		if index <= center2 {
+			return c.mean + (c2.mean-c.mean)*(index-center)/(center2-center)
+		}
+		cumulative += c.weight
+	}
+
+	return last.mean
+}
@@ -0,0 +1,90 @@
+package flinx
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/kom0055/go-flinx/generics"
+)
+
+func TestApproxCountDistinct(t *testing.T) {
+	hash := func(i int) uint64 { return uint64(i) }
+
+	for _, precision := range []uint8{10, 12, 14} {
+		stdErr := 1.04 / math.Sqrt(float64(int(1)<<precision))
+		for _, n := range []int{100, 5000, 200000} {
+			// Every value is repeated three times.
+			input := Select(func(i int) int { return i % n })(Range(0, 3*n))
+			got := ApproxCountDistinct(precision, hash)(input)
+
+			if relErr := math.Abs(float64(got)-float64(n)) / float64(n); relErr > 3*stdErr {
+				t.Errorf("ApproxCountDistinct(%d)=%d expected %d within %.2f%%", precision, got, n, 300*stdErr)
+			}
+		}
+	}
+
+	words := Select(func(i int) string { return "word" + strconv.Itoa(i%1000) })(Range(0, 10000))
+	got := ApproxCountDistinct(14, generics.StringHash)(words)
+	assert.Assert(t, got >= 975 && got <= 1025, got)
+
+	assert.Equal(t, ApproxCountDistinct(4, hash)(FromSlice([]int{})), uint64(0))
+	assert.Equal(t, ApproxCountDistinct(0, hash)(FromSlice([]int{7, 7, 7})), uint64(1))
+}
+
+func TestApproxQuantiles(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	input := make([]float64, 100000)
+	for i := range input {
+		input[i] = r.NormFloat64()*10 + 50
+	}
+	sorted := append([]float64{}, input...)
+	sort.Float64s(sorted)
+
+	quantiles := []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+	got := ApproxQuantiles(100, Self[float64], quantiles...)(FromSlice(input))
+
+	for i, q := range quantiles {
+		rank := float64(sort.SearchFloat64s(sorted, got[i])) / float64(len(sorted))
+		// The documented rank error is 0.5% around the median, tighter towards the tails.
+		bound := 0.005
+		if q < 0.05 || q > 0.95 {
+			bound = 0.001
+		}
+		if math.Abs(rank-q) > bound {
+			t.Errorf("ApproxQuantiles(%v)=%v has rank %v", q, got[i], rank)
+		}
+	}
+
+	extremes := ApproxQuantiles(100, Self[float64], 0, 1)(FromSlice(input))
+	assert.DeepEqual(t, extremes, []float64{sorted[0], sorted[len(sorted)-1]})
+}
+
+func TestApproxQuantilesSmall(t *testing.T) {
+	got := ApproxQuantiles(100, getF1, 0, 0.5, 1)(FromSlice([]foo{{f1: 3}, {f1: 1}, {f1: 2}}))
+	assert.DeepEqual(t, got, []float64{1, 2, 3})
+
+	got = ApproxQuantiles(100, Self[int], 0.5, 1.5)(FromSlice([]int{}))
+	assert.Assert(t, math.IsNaN(got[0]) && math.IsNaN(got[1]))
+
+	got = ApproxQuantiles(100, Self[int], -0.1, 0.5, math.NaN(), 50)(FromSlice([]int{4}))
+	assert.Assert(t, math.IsNaN(got[0]))
+	assertFloat(t, got[1], 4)
+	assert.Assert(t, math.IsNaN(got[2]))
+	assert.Assert(t, math.IsNaN(got[3]))
+}
+
+func TestTDigestBounded(t *testing.T) {
+	d := &tdigest{compression: 100, min: math.Inf(1), max: math.Inf(-1)}
+	for i := 0; i < 1000000; i++ {
+		d.add(float64(i * 7919 % 1000003))
+	}
+	d.compress()
+
+	assert.Assert(t, len(d.centroids) <= 100, len(d.centroids))
+	assert.Assert(t, cap(d.buffer) <= 1000, cap(d.buffer))
+}
@@ -0,0 +1,181 @@
+package flinx
+
+// Chunk splits the elements of a collection into slices of the given size. The
+// last slice may be shorter if the number of elements is not a multiple of
+// size. Chunk panics if size is not positive.
+//
+// Chunk only pulls the elements of the chunk being emitted, so it can be used
+// on unbounded sources such as a channel.
+func Chunk[T any](size int) func(q Query[T]) Query[[]T] {
+	if size <= 0 {
+		panic("flinx: Chunk size must be positive")
+	}
+
+	return func(q Query[T]) Query[[]T] {
+		return Query[[]T]{
+			Iterate: func() Iterator[[]T] {
+				next := q.Iterate()
+				done := false
+
+				return func() (item []T, ok bool) {
+					if done {
+						return
+					}
+
+					for len(item) < size {
+						it, more := next()
+						if !more {
+							done = true
+							break
+						}
+						if item == nil {
+							item = make([]T, 0, size)
+						}
+						item = append(item, it)
+					}
+
+					return item, len(item) > 0
+				}
+			},
+		}
+	}
+
+}
+
+// SlidingWindow emits windows of size consecutive elements, the start of each
+// window being step elements after the start of the previous one. Windows
+// overlap if step is smaller than size, and elements are skipped if step is
+// greater than size. Only full windows are emitted. SlidingWindow panics if
+// size or step is not positive.
+//
+// Every window is a new slice, so it can be retained by the consumer.
+func SlidingWindow[T any](size, step int) func(q Query[T]) Query[[]T] {
+	if size <= 0 || step <= 0 {
+		panic("flinx: SlidingWindow size and step must be positive")
+	}
+
+	return func(q Query[T]) Query[[]T] {
+		return Query[[]T]{
+			Iterate: func() Iterator[[]T] {
+				next := q.Iterate()
+				var window []T
+				skip := 0
+				done := false
+
+				return func() (item []T, ok bool) {
+					for !done {
+						it, more := next()
+						if !more {
+							done = true
+							break
+						}
+
+						if skip > 0 {
+							skip--
+							continue
+						}
+
+						window = append(window, it)
+						if len(window) < size {
+							continue
+						}
+
+						item = make([]T, size)
+						copy(item, window)
+						if step < size {
+							window = append(window[:0], window[step:]...)
+						} else {
+							window = window[:0]
+							skip = step - size
+						}
+
+						return item, true
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
+
+// Pairwise applies resultSelector to each element of a collection and its
+// predecessor, producing a collection of the results. The result has one
+// element less than the source, and is empty if the source has less than two
+// elements.
+func Pairwise[T, V any](resultSelector func(previous, current T) V) func(q Query[T]) Query[V] {
+	return func(q Query[T]) Query[V] {
+		return Query[V]{
+			Iterate: func() Iterator[V] {
+				next := q.Iterate()
+				var previous T
+				started, done := false, false
+
+				return func() (item V, ok bool) {
+					if done {
+						return
+					}
+
+					if !started {
+						started = true
+						if previous, ok = next(); !ok {
+							done = true
+							return
+						}
+					}
+
+					current, ok := next()
+					if !ok {
+						done = true
+						return
+					}
+
+					item = resultSelector(previous, current)
+					previous = current
+					return item, true
+				}
+			},
+		}
+	}
+
+}
+
+// ChunkBy splits the elements of a collection into slices of consecutive
+// elements. A new slice is started each time belongs returns false for an
+// element and its predecessor.
+//
+// ChunkBy only buffers the elements of the chunk being emitted, so it can be
+// used on unbounded sources such as a channel.
+func ChunkBy[T any](belongs func(previous, current T) bool) func(q Query[T]) Query[[]T] {
+	return func(q Query[T]) Query[[]T] {
+		return Query[[]T]{
+			Iterate: func() Iterator[[]T] {
+				next := q.Iterate()
+				var pending T
+				started, hasPending := false, false
+
+				return func() (item []T, ok bool) {
+					if !started {
+						started = true
+						pending, hasPending = next()
+					}
+					if !hasPending {
+						return
+					}
+
+					item = []T{pending}
+					for pending, hasPending = next(); hasPending; pending, hasPending = next() {
+						if !belongs(item[len(item)-1], pending) {
+							break
+						}
+						item = append(item, pending)
+					}
+
+					return item, true
+				}
+			},
+		}
+	}
+
+}
@@ -0,0 +1,84 @@
+package flinx
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		input  []int
+		size   int
+		output [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{[]int{1, 2}, 5, [][]int{{1, 2}}},
+		{[]int{}, 3, [][]int{}},
+	}
+
+	for _, test := range tests {
+		assert.DeepEqual(t, ToSlice(Chunk[int](test.size)(FromSlice(test.input))), test.output)
+	}
+
+	c := make(chan int)
+	go func() {
+		for i := 0; i < 3; i++ {
+			c <- i
+		}
+	}()
+	first, ok := First(Chunk[int](3)(FromChannel(c)))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, first, []int{0, 1, 2})
+}
+
+func TestSlidingWindow(t *testing.T) {
+	tests := []struct {
+		input  []int
+		size   int
+		step   int
+		output [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 3, 1, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{[]int{1, 2, 3, 4, 5, 6}, 3, 2, [][]int{{1, 2, 3}, {3, 4, 5}}},
+		{[]int{1, 2, 3, 4, 5, 6}, 2, 2, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, 2, 3, [][]int{{1, 2}, {4, 5}}},
+		{[]int{1, 2}, 3, 1, [][]int{}},
+	}
+
+	for _, test := range tests {
+		q := SlidingWindow[int](test.size, test.step)(FromSlice(test.input))
+		assert.DeepEqual(t, ToSlice(q), test.output)
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	diff := func(previous, current int) int {
+		return current - previous
+	}
+
+	assert.DeepEqual(t, ToSlice(Pairwise(diff)(FromSlice([]int{1, 3, 6, 10}))), []int{2, 3, 4})
+	assert.DeepEqual(t, ToSlice(Pairwise(diff)(FromSlice([]int{1}))), []int{})
+	assert.DeepEqual(t, ToSlice(Pairwise(diff)(FromSlice([]int{}))), []int{})
+}
+
+func TestChunkBy(t *testing.T) {
+	consecutive := func(previous, current int) bool {
+		return current == previous+1
+	}
+
+	assert.DeepEqual(t, ToSlice(ChunkBy(consecutive)(FromSlice([]int{1, 2, 3, 5, 6, 8}))), [][]int{{1, 2, 3}, {5, 6}, {8}})
+	assert.DeepEqual(t, ToSlice(ChunkBy(consecutive)(FromSlice([]int{4}))), [][]int{{4}})
+	assert.DeepEqual(t, ToSlice(ChunkBy(consecutive)(FromSlice([]int{}))), [][]int{})
+
+	c := make(chan int)
+	go func() {
+		for _, i := range []int{1, 2, 4} {
+			c <- i
+		}
+	}()
+	first, ok := First(ChunkBy(consecutive)(FromChannel(c)))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, first, []int{1, 2})
+}
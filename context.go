@@ -0,0 +1,153 @@
+package flinx
+
+import "context"
+
+// WithContext stops iterating over a collection as soon as ctx is done. Items
+// are pulled from the source only while ctx is alive, so no upstream work is
+// done after cancellation.
+//
+// WithContext can not interrupt a source that is already blocked, use
+// FromChannelContext to build a query from a channel that can be cancelled.
+// Use the terminal functions ending with Context to find out whether the
+// iteration was cut short.
+func WithContext[T any](ctx context.Context) func(q Query[T]) Query[T] {
+	return func(q Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next, _ := contextIterator(ctx, q.Iterate())
+				return next
+			},
+		}
+	}
+
+}
+
+// FromChannelContext initializes a linq query with passed channel, linq
+// iterates over channel until it is closed or ctx is done, whichever happens
+// first.
+func FromChannelContext[T any](ctx context.Context, source <-chan T) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			return func() (item T, ok bool) {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok = <-source:
+					return
+				}
+			}
+		},
+	}
+}
+
+// CountContext returns the number of elements in a collection. It returns
+// ctx.Err() if ctx is done before the collection is exhausted.
+func CountContext[T any](ctx context.Context) func(q Query[T]) (int, error) {
+	return func(q Query[T]) (r int, err error) {
+		next, cancelled := contextIterator(ctx, q.Iterate())
+
+		for _, ok := next(); ok; _, ok = next() {
+			r++
+		}
+
+		return r, cancelled()
+	}
+
+}
+
+// FirstContext returns the first element of a collection. It returns ctx.Err()
+// if ctx is done before an element is found.
+func FirstContext[T any](ctx context.Context) func(q Query[T]) (T, bool, error) {
+	return func(q Query[T]) (T, bool, error) {
+		next, cancelled := contextIterator(ctx, q.Iterate())
+		item, ok := next()
+		if !ok {
+			return item, false, cancelled()
+		}
+
+		return item, true, nil
+	}
+
+}
+
+// ForEachContext performs the specified action on each element of a
+// collection. It returns ctx.Err() if ctx is done before the collection is
+// exhausted.
+func ForEachContext[T any](ctx context.Context, action func(T)) func(q Query[T]) error {
+	return func(q Query[T]) error {
+		next, cancelled := contextIterator(ctx, q.Iterate())
+
+		for item, ok := next(); ok; item, ok = next() {
+			action(item)
+		}
+
+		return cancelled()
+	}
+
+}
+
+// ToChannelContext iterates over a collection and outputs each element to a
+// channel, then closes it. It stops and returns ctx.Err() if ctx is done before
+// the collection is exhausted, even while blocked on sending to result.
+func ToChannelContext[T any](ctx context.Context, q Query[T], result chan<- T) error {
+	defer close(result)
+	next, cancelled := contextIterator(ctx, q.Iterate())
+
+	for item, ok := next(); ok; item, ok = next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case result <- item:
+		}
+	}
+
+	return cancelled()
+}
+
+// ToSliceContext iterates over a collection and saves the results in a slice.
+// It returns the items collected so far and ctx.Err() if ctx is done before the
+// collection is exhausted.
+func ToSliceContext[T any](ctx context.Context) func(q Query[T]) ([]T, error) {
+	return func(q Query[T]) ([]T, error) {
+		r := []T{}
+		next, cancelled := contextIterator(ctx, q.Iterate())
+
+		for item, ok := next(); ok; item, ok = next() {
+			r = append(r, item)
+		}
+
+		return r, cancelled()
+	}
+
+}
+
+// contextIterator pulls from next while ctx is alive. The returned cancelled
+// function reports ctx.Err() only if the iteration was cut short because of
+// ctx: an element was not pulled because ctx was done, or next ended while ctx
+// was done, as a context aware source like FromChannelContext does. Once next
+// is exhausted, ctx being done afterwards is not reported.
+func contextIterator[T any](ctx context.Context, next Iterator[T]) (Iterator[T], func() error) {
+	var err error
+	done := false
+
+	iterator := func() (item T, ok bool) {
+		if done {
+			return
+		}
+
+		if err = ctx.Err(); err != nil {
+			done = true
+			return
+		}
+
+		if item, ok = next(); !ok {
+			done = true
+			err = ctx.Err()
+		}
+		return
+	}
+
+	return iterator, func() error {
+		return err
+	}
+}
@@ -0,0 +1,160 @@
+package flinx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pulled := 0
+	q := WithContext[int](ctx)(Select(func(i int) int {
+		pulled++
+		if i == 3 {
+			cancel()
+		}
+		return i
+	})(Range(1, 1e9)))
+
+	assert.DeepEqual(t, ToSlice(q), []int{1, 2, 3})
+	assert.Equal(t, pulled, 3)
+}
+
+func TestFromChannelContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+
+	r, err := ToSliceContext[int](ctx)(FromChannelContext(ctx, c))
+	assert.DeepEqual(t, r, []int{1, 2})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(c)
+	r, err = ToSliceContext[int](context.Background())(FromChannelContext(context.Background(), c))
+	assert.DeepEqual(t, r, []int{})
+	assert.NilError(t, err)
+}
+
+func TestCountContext(t *testing.T) {
+	r, err := CountContext[int](context.Background())(Range(1, 10))
+	assert.Equal(t, r, 10)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := Select(func(i int) int {
+		if i == 5 {
+			cancel()
+		}
+		return i
+	})(Range(1, 10))
+	r, err = CountContext[int](ctx)(q)
+	assert.Equal(t, r, 5)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFirstContext(t *testing.T) {
+	r, ok, err := FirstContext[int](context.Background())(Range(3, 10))
+	assert.Equal(t, r, 3)
+	assert.Assert(t, ok)
+	assert.NilError(t, err)
+
+	_, ok, err = FirstContext[int](context.Background())(Range(3, 0))
+	assert.Assert(t, !ok)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, ok, err = FirstContext[int](ctx)(Range(3, 10))
+	assert.Assert(t, !ok)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestForEachContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var r []int
+	err := ForEachContext(ctx, func(i int) {
+		r = append(r, i)
+		if i == 2 {
+			cancel()
+		}
+	})(Range(1, 10))
+	assert.DeepEqual(t, r, []int{1, 2})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestToChannelContext(t *testing.T) {
+	c := make(chan int, 3)
+	assert.NilError(t, ToChannelContext(context.Background(), Range(1, 3), c))
+	assert.DeepEqual(t, ToSlice(FromChannel(c)), []int{1, 2, 3})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	blocked := make(chan int)
+	err := ToChannelContext(ctx, Range(1, 3), blocked)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, ok := <-blocked
+	assert.Assert(t, !ok)
+}
+
+func TestContextIteratorCancelled(t *testing.T) {
+	// ctx done after the source is exhausted is not reported.
+	ctx, cancel := context.WithCancel(context.Background())
+	next, cancelled := contextIterator(ctx, Range(1, 3).Iterate())
+	for _, ok := next(); ok; _, ok = next() {
+	}
+	cancel()
+	_, ok := next()
+	assert.Assert(t, !ok)
+	assert.NilError(t, cancelled())
+
+	// ctx done before the source is exhausted is reported.
+	ctx, cancel = context.WithCancel(context.Background())
+	next, cancelled = contextIterator(ctx, Range(1, 3).Iterate())
+	_, ok = next()
+	assert.Assert(t, ok)
+	cancel()
+	_, ok = next()
+	assert.Assert(t, !ok)
+	assert.ErrorIs(t, cancelled(), context.Canceled)
+
+	// A source ending because ctx is done is reported.
+	ctx, cancel = context.WithCancel(context.Background())
+	c := make(chan int)
+	next, cancelled = contextIterator(ctx, FromChannelContext(ctx, c).Iterate())
+	time.AfterFunc(time.Millisecond, cancel)
+	_, ok = next()
+	assert.Assert(t, !ok)
+	assert.ErrorIs(t, cancelled(), context.Canceled)
+}
+
+func TestContextExhausted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	r, err := ToSliceContext[int](ctx)(FromChannelContext(ctx, closedChannel(1, 2)))
+	assert.DeepEqual(t, r, []int{1, 2})
+	assert.NilError(t, err)
+
+	n, err := CountContext[int](ctx)(FromChannelContext(ctx, closedChannel(1, 2)))
+	assert.Equal(t, n, 2)
+	assert.NilError(t, err)
+}
+
+func closedChannel(items ...int) chan int {
+	c := make(chan int, len(items))
+	for _, item := range items {
+		c <- item
+	}
+	close(c)
+	return c
+}
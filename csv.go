@@ -0,0 +1,275 @@
+package flinx
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LineError is the error reported by a query reading a text format, such as
+// FromCSV or FromJSONLines, when a row cannot be parsed. Line is the one-based
+// line number of the row in the input.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// csvField is an exported field of a struct mapped to a CSV column.
+type csvField struct {
+	name  string
+	index int
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// csvFields returns the fields of struct type t mapped to CSV columns, that is
+// its exported fields whose tag is not "-", named after their tag if any.
+func csvFields(t reflect.Type) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("flinx: CSV type %v is not a struct", t)
+	}
+
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("csv"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if !csvSupported(f.Type) {
+			return nil, fmt.Errorf("flinx: CSV field %s has unsupported type %v", f.Name, f.Type)
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, csvField{name: name, index: i})
+	}
+
+	return fields, nil
+}
+
+func csvSupported(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) && t.Implements(textMarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func parseCSVValue(v reflect.Value, s string) (err error) {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, v.Type().Bits())
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, v.Type().Bits())
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+	}
+
+	return
+}
+
+func formatCSVValue(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+}
+
+// FromCSV initializes a fallible query with passed reader of CSV data, linq
+// iterates over its records decoded into values of struct type T.
+//
+// The first record is the header, each column is stored in the exported field
+// of T whose csv tag, or name if it has no tag, matches the column name.
+// Fields tagged with "-", and columns matching no field, are ignored; fields
+// matching no column keep their zero value. Fields can be strings, booleans,
+// numbers, or implement both encoding.TextMarshaler and
+// encoding.TextUnmarshaler, such as time.Time.
+//
+// Records that are malformed or whose values cannot be parsed end the
+// iteration with a LineError holding the line of the record. The reader is
+// read lazily, and only once: iterating the query again continues where the
+// previous iteration stopped.
+func FromCSV[T any](r io.Reader) TryQuery[T] {
+	reader := csv.NewReader(r)
+	fields, failed := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	var columns []*csvField
+	started := false
+
+	// The reader is shared by all the iterations, so is the first error.
+	fail := func(err error) error {
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			err = &LineError{Line: pe.Line, Err: pe.Err}
+		}
+		failed = err
+		return err
+	}
+
+	return TryQuery[T]{
+		Iterate: func() TryIterator[T] {
+			return func() (item T, ok bool, err error) {
+				if failed != nil {
+					return item, false, failed
+				}
+
+				if !started {
+					started = true
+					header, err := reader.Read()
+					if err == io.EOF {
+						return item, false, nil
+					}
+					if err != nil {
+						return item, false, fail(err)
+					}
+
+					columns = make([]*csvField, len(header))
+					for i, name := range header {
+						for j := range fields {
+							if fields[j].name == name {
+								columns[i] = &fields[j]
+								break
+							}
+						}
+					}
+				}
+
+				record, err := reader.Read()
+				if err == io.EOF {
+					return item, false, nil
+				}
+				if err != nil {
+					return item, false, fail(err)
+				}
+
+				v := reflect.ValueOf(&item).Elem()
+				for i, s := range record {
+					if i >= len(columns) || columns[i] == nil {
+						continue
+					}
+					if err := parseCSVValue(v.Field(columns[i].index), s); err != nil {
+						line, _ := reader.FieldPos(i)
+						var zero T
+						return zero, false, fail(&LineError{Line: line,
+							Err: fmt.Errorf("column %s: %w", columns[i].name, err)})
+					}
+				}
+
+				return item, true, nil
+			}
+		},
+	}
+}
+
+// WriteCSV writes the elements of a collection of struct type T to w as CSV
+// data, starting with a header. The columns are the fields mapped by FromCSV,
+// in their declaration order. If an element cannot be formatted, the error is
+// wrapped in an ElementError holding its index.
+func WriteCSV[T any](w io.Writer) func(q Query[T]) error {
+	write := TryWriteCSV[T](w)
+	return func(q Query[T]) error {
+		return write(AsTry(q))
+	}
+}
+
+// TryWriteCSV writes the elements of a fallible collection to w like WriteCSV
+// does. It stops at the first error of the collection, and returns it.
+func TryWriteCSV[T any](w io.Writer) func(q TryQuery[T]) error {
+	return func(q TryQuery[T]) error {
+		fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+		if err != nil {
+			return err
+		}
+
+		writer := csv.NewWriter(w)
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i] = f.name
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		releaseScoped(func(*releaseScope) {
+			next := q.iterateIndexed()
+
+			var (
+				item  T
+				index int
+				ok    bool
+			)
+			for item, index, ok, err = next(); ok; item, index, ok, err = next() {
+				v := reflect.ValueOf(item)
+				for i, f := range fields {
+					s, ferr := formatCSVValue(v.Field(f.index))
+					if ferr != nil {
+						err = &ElementError{Index: index, Err: ferr}
+						return
+					}
+					record[i] = s
+				}
+				if err = writer.Write(record); err != nil {
+					return
+				}
+			}
+		})
+
+		writer.Flush()
+		if err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+
+}
@@ -0,0 +1,162 @@
+package flinx
+
+import (
+	"encoding/csv"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/v3/assert"
+)
+
+type csvRecord struct {
+	Name    string    `csv:"name"`
+	Age     int       `csv:"age"`
+	Score   float64   `csv:"score"`
+	Active  bool      `csv:"active"`
+	Created time.Time `csv:"created"`
+	Note    string
+	Ignored string `csv:"-"`
+	hidden  string
+}
+
+var cmpCSVRecord = cmp.AllowUnexported(csvRecord{})
+
+func TestFromCSV(t *testing.T) {
+	input := "age,name,extra,score,active,created,Note\n" +
+		"42,Alice,x,1.5,true,2024-01-02T03:04:05Z,\"a, b\"\n" +
+		"7,Bob,y,-2,false,2024-02-03T00:00:00Z,\n"
+	want := []csvRecord{
+		{Name: "Alice", Age: 42, Score: 1.5, Active: true, Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Note: "a, b"},
+		{Name: "Bob", Age: 7, Score: -2, Created: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	r, err := TryToSlice(FromCSV[csvRecord](strings.NewReader(input)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, want, cmpCSVRecord)
+
+	// Missing columns keep their zero value.
+	r, err = TryToSlice(FromCSV[csvRecord](strings.NewReader("name\nCarol\n")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []csvRecord{{Name: "Carol"}}, cmpCSVRecord)
+
+	r, err = TryToSlice(FromCSV[csvRecord](strings.NewReader("")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []csvRecord{}, cmpCSVRecord)
+}
+
+func TestFromCSVLazy(t *testing.T) {
+	q := FromCSV[csvRecord](strings.NewReader("name,age\na,1\nb,2\nc,x\n"))
+
+	first, ok, err := TryFirst(q)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, first.Name, "a")
+
+	next := q.Iterate()
+	item, ok, err := next()
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, item.Name, "b")
+
+	_, ok, err = next()
+	assert.Assert(t, !ok)
+	assert.ErrorContains(t, err, "line 4: column age")
+}
+
+func TestFromCSVError(t *testing.T) {
+	var lineErr *LineError
+
+	_, err := TryToSlice(FromCSV[csvRecord](strings.NewReader("name,age\na,1\nb,2\nc,old\n")))
+	assert.Assert(t, errors.As(err, &lineErr))
+	assert.Equal(t, lineErr.Line, 4)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	_, err = TryToSlice(FromCSV[csvRecord](strings.NewReader("name,age\na,1\nb\n")))
+	assert.Assert(t, errors.As(err, &lineErr))
+	assert.Equal(t, lineErr.Line, 3)
+	assert.ErrorIs(t, err, csv.ErrFieldCount)
+
+	_, err = TryToSlice(FromCSV[csvRecord](strings.NewReader("name,age\na,1\n\"b\n")))
+	assert.Assert(t, errors.As(err, &lineErr))
+	assert.Equal(t, lineErr.Line, 3)
+	assert.ErrorIs(t, err, csv.ErrQuote)
+
+	_, err = TryToSlice(FromCSV[csvRecord](iotest.ErrReader(errRead)))
+	assert.ErrorIs(t, err, errRead)
+
+	_, err = TryToSlice(FromCSV[int](strings.NewReader("a\n1\n")))
+	assert.ErrorContains(t, err, "is not a struct")
+
+	type unsupported struct {
+		Values []int
+	}
+	_, err = TryToSlice(FromCSV[unsupported](strings.NewReader("Values\n1\n")))
+	assert.ErrorContains(t, err, "unsupported type")
+}
+
+func TestFromCSVErrorSticks(t *testing.T) {
+	q := FromCSV[int](strings.NewReader("a\n1\n2\n"))
+	for i := 0; i < 2; i++ {
+		r, err := TryToSlice(q)
+		assert.Assert(t, r == nil)
+		assert.ErrorContains(t, err, "is not a struct")
+	}
+
+	type row struct {
+		A int
+	}
+	q2 := FromCSV[row](strings.NewReader("A\nx\n2\n"))
+	for i := 0; i < 2; i++ {
+		r, err := TryToSlice(q2)
+		assert.Assert(t, r == nil)
+		assert.ErrorContains(t, err, "line 2: column A")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	input := []csvRecord{
+		{Name: "Alice", Age: 42, Score: 1.5, Active: true, Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Note: "a, b"},
+		{Name: "Bob", Age: 7, Score: -2, Created: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), Ignored: "x"},
+	}
+	want := "name,age,score,active,created,Note\n" +
+		"Alice,42,1.5,true,2024-01-02T03:04:05Z,\"a, b\"\n" +
+		"Bob,7,-2,false,2024-02-03T00:00:00Z,\n"
+
+	var b strings.Builder
+	assert.NilError(t, WriteCSV[csvRecord](&b)(FromSlice(input)))
+	assert.Equal(t, b.String(), want)
+
+	r, err := TryToSlice(FromCSV[csvRecord](strings.NewReader(b.String())))
+	assert.NilError(t, err)
+	input[1].Ignored = ""
+	assert.DeepEqual(t, r, input, cmpCSVRecord)
+}
+
+func TestTryWriteCSV(t *testing.T) {
+	type point struct {
+		X float64
+	}
+
+	var b strings.Builder
+	err := TryWriteCSV[point](&b)(FromCSV[point](strings.NewReader("X\n1\nNaN\nx\n")))
+	assert.ErrorContains(t, err, "line 4")
+	assert.Equal(t, b.String(), "X\n1\nNaN\n")
+
+	b.Reset()
+	assert.NilError(t, WriteCSV[point](&b)(FromSlice([]point{{math.Inf(1)}, {0.25}})))
+	assert.Equal(t, b.String(), "X\n+Inf\n0.25\n")
+
+	assert.ErrorIs(t, WriteCSV[point](errWriter{})(FromSlice([]point{{1}})), errRead)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errRead
+}
@@ -1,6 +1,9 @@
 package flinx
 
-import "github.com/kom0055/go-flinx/hashset"
+import (
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashset"
+)
 
 // Distinct method returns distinct elements from a collection. The result is an
 // unordered collection that contains no duplicate values.
@@ -79,3 +82,29 @@ func DistinctBy[T any, V comparable](selector func(T) V) func(q Query[T]) Query[
 	}
 
 }
+
+// DistinctUsing method returns distinct elements from a collection, using
+// comparer to find equal elements, so it works for elements that are not
+// comparable with ==. The first of equal elements is kept, and the result keeps
+// the order of the collection.
+func DistinctUsing[T any](comparer generics.EqualityComparer[T]) func(q Query[T]) Query[T] {
+	return func(q Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next := q.Iterate()
+				set := newHashTable(comparer)
+
+				return func() (item T, ok bool) {
+					for item, ok = next(); ok; item, ok = next() {
+						if set.Insert(item) {
+							return
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
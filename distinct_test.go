@@ -3,6 +3,7 @@ package flinx
 import (
 	"testing"
 
+	"github.com/kom0055/go-flinx/generics"
 	"gotest.tools/v3/assert"
 )
 
@@ -93,3 +94,13 @@ func TestDistinctBy(t *testing.T) {
 		t.Errorf("From(%v).DistinctBy()=%v expected %v", users, ToSlice(q), want)
 	}
 }
+
+func TestDistinctUsing(t *testing.T) {
+	input := [][]int{{1, 2}, {3}, {1, 2}, {2, 1}, {3}, {}}
+	want := [][]int{{1, 2}, {3}, {2, 1}, {}}
+	assert.DeepEqual(t, ToSlice(DistinctUsing(sliceComparer)(FromSlice(input))), want)
+
+	words := []string{"Go", "go", "Rust", "GO", "rust", "Zig"}
+	assert.DeepEqual(t, ToSlice(DistinctUsing(generics.CaseInsensitiveEqualityComparer())(FromSlice(words))),
+		[]string{"Go", "Rust", "Zig"})
+}
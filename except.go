@@ -1,6 +1,9 @@
 package flinx
 
-import "github.com/kom0055/go-flinx/hashset"
+import (
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashset"
+)
 
 // Except produces the set difference of two sequences. The set difference is
 // the members of the first sequence that don't appear in the second sequence.
@@ -62,3 +65,33 @@ func ExceptBy[T any, V comparable](selector func(T) V) func(q, q2 Query[T]) Quer
 	}
 
 }
+
+// ExceptUsing produces the set difference of two sequences, using comparer to
+// find equal elements, so it works for elements that are not comparable with
+// ==.
+func ExceptUsing[T any](comparer generics.EqualityComparer[T]) func(q, q2 Query[T]) Query[T] {
+	return func(q, q2 Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next := q.Iterate()
+
+				next2 := q2.Iterate()
+				set := newHashTable(comparer)
+				for i, ok := next2(); ok; i, ok = next2() {
+					set.Insert(i)
+				}
+
+				return func() (item T, ok bool) {
+					for item, ok = next(); ok; item, ok = next() {
+						if !set.Has(item) {
+							return
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
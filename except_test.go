@@ -1,6 +1,9 @@
 package flinx
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestExcept(t *testing.T) {
 	input1 := []int{1, 2, 3, 4, 5, 1, 2, 5}
@@ -23,3 +26,13 @@ func TestExceptBy(t *testing.T) {
 		t.Errorf("From(%v).ExceptBy(%v)=%v expected %v", input1, input2, ToSlice(q), want)
 	}
 }
+
+func TestExceptUsing(t *testing.T) {
+	input1 := [][]int{{1}, {2}, {1, 2}, {2}}
+	input2 := [][]int{{1, 2}, {3}}
+	want := [][]int{{1}, {2}, {2}}
+
+	if q := ExceptUsing(sliceComparer)(FromSlice(input1), FromSlice(input2)); !reflect.DeepEqual(ToSlice(q), want) {
+		t.Errorf("From(%v).ExceptUsing(%v)=%v expected %v", input1, input2, ToSlice(q), want)
+	}
+}
@@ -0,0 +1,362 @@
+package flinx
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"os"
+	"sort"
+)
+
+// Codec encodes and decodes the elements that ExternalSort spills to disk.
+type Codec[T any] interface {
+	Encode(t T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// externalSortFanIn is the maximum number of runs merged at once, which bounds
+// the number of files open while merging.
+const externalSortFanIn = 64
+
+// ExternalSort sorts the elements of a collection in ascending order according
+// to compare, for collections that don't fit in memory.
+//
+// Elements are buffered until their encoded size reaches memoryBudget bytes,
+// then the buffer is sorted and written to a temporary file using codec. Once
+// the collection is exhausted, the sorted runs are merged lazily while
+// iterating. If the whole collection fits in memoryBudget, nothing is written
+// to disk. The buffer holds both the elements and their encoding, so the
+// memory used is greater than memoryBudget.
+//
+// The temporary files are written to a new directory created in dir, or in
+// os.TempDir if dir is empty. As the default directory is often memory-backed,
+// like a tmpfs /tmp, collections that don't fit in memory should be sorted in
+// a dir on disk.
+//
+// At most 64 runs are merged at once: if there are more runs, they are first
+// merged into larger runs, so the number of open files stays bounded whatever
+// the size of the collection.
+//
+// The temporary files are removed once the iteration is over or has failed. If
+// it stops early, they are removed as soon as the for range loop over
+// TryQuery.All breaks, or the function consuming the query returns, like
+// TryFirst, or TryToSlice when a later stage fails. An iterator pulled manually
+// and abandoned has its files removed once it is garbage collected.
+//
+// Like OrderBy, the sort is stable. Errors returned by the Encode method of
+// codec are wrapped in an ElementError holding the index of the element, errors
+// returned by its Decode method are reported as is.
+func ExternalSort[T any](compare func(T, T) int, codec Codec[T], memoryBudget int, dir string) func(q Query[T]) TryQuery[T] {
+	return func(q Query[T]) TryQuery[T] {
+		return TryQuery[T]{
+			Iterate: func() TryIterator[T] {
+				s := &externalSorter[T]{compare: compare, codec: codec, fanIn: externalSortFanIn, tempDir: dir}
+				r := newReleaser(s.close)
+				var next TryIterator[T]
+
+				return func() (item T, ok bool, err error) {
+					if next == nil {
+						if next, err = s.sort(q, memoryBudget); err != nil {
+							r.release()
+							next = func() (item T, ok bool, _ error) {
+								return item, false, err
+							}
+							return item, false, err
+						}
+					}
+
+					if item, ok, err = next(); !ok {
+						r.release()
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
+
+type externalSorter[T any] struct {
+	compare func(T, T) int
+	codec   Codec[T]
+	fanIn   int
+	// tempDir is where dir is created, dir holds the run files.
+	tempDir string
+	dir     string
+	// runs are the paths of the sorted run files, in the order they were
+	// written, and files are the run files currently open for merging.
+	runs  []string
+	files []*os.File
+}
+
+type externalSortEntry[T any] struct {
+	item T
+	data []byte
+}
+
+func (s *externalSorter[T]) close() {
+	s.closeFiles()
+	if s.dir != "" {
+		_ = os.RemoveAll(s.dir)
+	}
+}
+
+func (s *externalSorter[T]) closeFiles() {
+	for _, f := range s.files {
+		_ = f.Close()
+	}
+	s.files = nil
+}
+
+func (s *externalSorter[T]) sort(q Query[T], memoryBudget int) (TryIterator[T], error) {
+	var buffer []externalSortEntry[T]
+	size := 0
+	index := 0
+
+	next := q.Iterate()
+	for item, ok := next(); ok; item, ok = next() {
+		data, err := s.codec.Encode(item)
+		if err != nil {
+			return nil, &ElementError{Index: index, Err: err}
+		}
+		index++
+
+		buffer = append(buffer, externalSortEntry[T]{item, data})
+		size += len(data)
+		if size >= memoryBudget {
+			if err = s.spill(buffer); err != nil {
+				return nil, err
+			}
+			buffer = buffer[:0]
+			size = 0
+		}
+	}
+
+	if len(s.runs) == 0 {
+		s.sortEntries(buffer)
+		items := make([]T, len(buffer))
+		for i, entry := range buffer {
+			items[i] = entry.item
+		}
+
+		return AsTry(FromSlice(items)).Iterate(), nil
+	}
+
+	if len(buffer) > 0 {
+		if err := s.spill(buffer); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.merge()
+}
+
+func (s *externalSorter[T]) sortEntries(entries []externalSortEntry[T]) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return s.compare(entries[i].item, entries[j].item) < 0
+	})
+}
+
+// createRun creates a new empty run file in the temporary directory.
+func (s *externalSorter[T]) createRun() (*os.File, error) {
+	if s.dir == "" {
+		dir, err := os.MkdirTemp(s.tempDir, "flinx-sort-")
+		if err != nil {
+			return nil, err
+		}
+		s.dir = dir
+	}
+
+	return os.CreateTemp(s.dir, "run-")
+}
+
+// writeEntry writes an encoded element to a run, prefixed with its length.
+func writeEntry(w *bufio.Writer, data []byte) error {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(data)))
+	if _, err := w.Write(length[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// closeRun flushes and closes a run file.
+func closeRun(f *os.File, w *bufio.Writer) error {
+	err := w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// spill sorts the entries and writes them to a new run file, which is closed
+// until the runs are merged.
+func (s *externalSorter[T]) spill(entries []externalSortEntry[T]) error {
+	f, err := s.createRun()
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, f.Name())
+
+	s.sortEntries(entries)
+	w := bufio.NewWriter(f)
+	for _, entry := range entries {
+		if err = writeEntry(w, entry.data); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	return closeRun(f, w)
+}
+
+// merge merges the runs by groups of at most fanIn consecutive runs until
+// there are no more than fanIn runs, which are then merged lazily. Merging
+// consecutive runs keeps the order of the runs, so the sort stays stable.
+func (s *externalSorter[T]) merge() (TryIterator[T], error) {
+	for len(s.runs) > s.fanIn {
+		var runs []string
+		for start := 0; start < len(s.runs); start += s.fanIn {
+			end := start + s.fanIn
+			if end > len(s.runs) {
+				end = len(s.runs)
+			}
+
+			run, err := s.mergeRuns(s.runs[start:end])
+			if err != nil {
+				return nil, err
+			}
+			runs = append(runs, run)
+		}
+		s.runs = runs
+	}
+
+	next, err := s.open(s.runs)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (item T, ok bool, err error) {
+		entry, ok, err := next()
+		return entry.item, ok, err
+	}, nil
+}
+
+// mergeRuns merges the given runs into a new run, and removes them.
+func (s *externalSorter[T]) mergeRuns(runs []string) (string, error) {
+	if len(runs) == 1 {
+		return runs[0], nil
+	}
+
+	next, err := s.open(runs)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := s.createRun()
+	if err != nil {
+		return "", err
+	}
+
+	w := bufio.NewWriter(f)
+	entry, ok, err := next()
+	for ; ok; entry, ok, err = next() {
+		if err = writeEntry(w, entry.data); err != nil {
+			break
+		}
+	}
+	if cerr := closeRun(f, w); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s.closeFiles()
+	for _, run := range runs {
+		if err = os.Remove(run); err != nil {
+			return "", err
+		}
+	}
+
+	return f.Name(), nil
+}
+
+// open opens the given runs and performs a lazy k-way merge of them. Equal
+// elements are taken from the earliest run first, which keeps the sort stable.
+func (s *externalSorter[T]) open(runs []string) (TryIterator[externalSortEntry[T]], error) {
+	readers := make([]*bufio.Reader, len(runs))
+	// rankHeap keeps the greatest item according to less at its root, so the
+	// order is reversed to pop the smallest item first.
+	h := &rankHeap[externalSortEntry[T]]{
+		less: func(r1, r2 rankedItem[externalSortEntry[T]]) bool {
+			if c := s.compare(r1.item.item, r2.item.item); c != 0 {
+				return c > 0
+			}
+			return r1.index > r2.index
+		},
+	}
+
+	for i, run := range runs {
+		f, err := os.Open(run)
+		if err != nil {
+			return nil, err
+		}
+		s.files = append(s.files, f)
+
+		readers[i] = bufio.NewReader(f)
+		entry, ok, err := s.read(readers[i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			heap.Push(h, rankedItem[externalSortEntry[T]]{entry, i})
+		}
+	}
+
+	var failed error
+
+	return func() (item externalSortEntry[T], ok bool, err error) {
+		if failed != nil || h.Len() == 0 {
+			return item, false, failed
+		}
+
+		top := h.items[0]
+		next, ok, err := s.read(readers[top.index])
+		switch {
+		case err != nil:
+			failed = err
+			return item, false, err
+		case ok:
+			h.items[0] = rankedItem[externalSortEntry[T]]{next, top.index}
+			heap.Fix(h, 0)
+		default:
+			heap.Pop(h)
+		}
+
+		return top.item, true, nil
+	}, nil
+}
+
+func (s *externalSorter[T]) read(r *bufio.Reader) (entry externalSortEntry[T], ok bool, err error) {
+	length, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return entry, false, nil
+	}
+	if err != nil {
+		return entry, false, err
+	}
+
+	entry.data = make([]byte, length)
+	if _, err = io.ReadFull(r, entry.data); err != nil {
+		return entry, false, err
+	}
+
+	if entry.item, err = s.codec.Decode(entry.data); err != nil {
+		return entry, false, err
+	}
+	return entry, true, nil
+}
@@ -0,0 +1,191 @@
+package flinx
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kom0055/go-flinx/generics"
+	"gotest.tools/v3/assert"
+)
+
+type fooCodec struct {
+	failEncode int
+	failDecode int
+}
+
+func (c fooCodec) Encode(f foo) ([]byte, error) {
+	if f.f1 == c.failEncode {
+		return nil, errors.New("encode")
+	}
+	return []byte(strconv.Itoa(f.f1) + "," + f.f3), nil
+}
+
+func (c fooCodec) Decode(data []byte) (foo, error) {
+	for i, b := range data {
+		if b == ',' {
+			f1, err := strconv.Atoi(string(data[:i]))
+			if err == nil && f1 == c.failDecode {
+				err = errors.New("decode")
+			}
+			return foo{f1: f1, f3: string(data[i+1:])}, err
+		}
+	}
+	return foo{}, errors.New("malformed")
+}
+
+func compareF1(f1, f2 foo) int {
+	return generics.OrderedCompare(f1.f1, f2.f1)
+}
+
+func randomFoos(n int) []foo {
+	slice := make([]foo, n)
+	for i := range slice {
+		slice[i].f1 = rand.Intn(100)
+		slice[i].f3 = strconv.Itoa(i)
+	}
+	return slice
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
+
+func TestExternalSort(t *testing.T) {
+	dir := t.TempDir()
+
+	slice := randomFoos(1000)
+	want := make([]foo, len(slice))
+	copy(want, slice)
+	sort.SliceStable(want, func(i, j int) bool {
+		return want[i].f1 < want[j].f1
+	})
+
+	for _, budget := range []int{1, 100, 1 << 20} {
+		r, err := TryToSlice(ExternalSort[foo](compareF1, fooCodec{-1, -1}, budget, dir)(FromSlice(slice)))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, r, want, cmpFoo)
+		assertNoTempFiles(t, dir)
+	}
+
+	r, err := TryToSlice(ExternalSort[foo](compareF1, fooCodec{-1, -1}, 1, dir)(FromSlice([]foo{})))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []foo{}, cmpFoo)
+}
+
+func TestExternalSortFanIn(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	slice := randomFoos(500)
+	want := make([]foo, len(slice))
+	copy(want, slice)
+	sort.SliceStable(want, func(i, j int) bool {
+		return want[i].f1 < want[j].f1
+	})
+
+	// 500 runs are merged by 4 in 5 passes, down to 2 runs.
+	s := &externalSorter[foo]{compare: compareF1, codec: fooCodec{-1, -1}, fanIn: 4}
+	next, err := s.sort(FromSlice(slice), 1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(s.runs), 2)
+	assert.Equal(t, len(s.files), 2)
+	entries, err := os.ReadDir(s.dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+
+	var r []foo
+	item, ok, err := next()
+	for ; ok; item, ok, err = next() {
+		r = append(r, item)
+	}
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, want, cmpFoo)
+
+	s.close()
+	assertNoTempFiles(t, dir)
+}
+
+func TestExternalSortErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	slice := []foo{{f1: 3}, {f1: 2}, {f1: 1}}
+
+	_, err := TryToSlice(ExternalSort[foo](compareF1, fooCodec{2, -1}, 1, "")(FromSlice(slice)))
+	assert.Error(t, err, "element 1: encode")
+	assertNoTempFiles(t, dir)
+
+	_, err = TryToSlice(ExternalSort[foo](compareF1, fooCodec{-1, 2}, 1, "")(FromSlice(slice)))
+	assert.Error(t, err, "decode")
+	assertNoTempFiles(t, dir)
+}
+
+func TestExternalSortStoppedEarly(t *testing.T) {
+	dir := t.TempDir()
+	q := Select(func(i int) foo {
+		return foo{f1: i}
+	})(Range(0, 100))
+	sorted := ExternalSort[foo](compareF1, fooCodec{-1, -1}, 10, dir)(Reverse(q))
+
+	first, ok, err := TryFirst(sorted)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, first.f1, 0)
+	assertNoTempFiles(t, dir)
+
+	failing := TrySelect(func(f foo) (int, error) {
+		if f.f1 == 3 {
+			return 0, errors.New("select")
+		}
+		return f.f1, nil
+	})(sorted)
+	_, err = TryToSlice(failing)
+	assert.Error(t, err, "element 3: select")
+	assertNoTempFiles(t, dir)
+
+	err = TryForEach(func(f foo) error {
+		return errors.New("action")
+	})(sorted)
+	assert.Error(t, err, "element 0: action")
+	assertNoTempFiles(t, dir)
+}
+
+// TestExternalSortAbandoned checks the fallback for iterators that are pulled
+// manually and abandoned.
+func TestExternalSortAbandoned(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	func() {
+		q := Select(func(i int) foo {
+			return foo{f1: i}
+		})(Range(0, 100))
+		first, ok, err := ExternalSort[foo](compareF1, fooCodec{-1, -1}, 10, "")(Reverse(q)).Iterate()()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.Equal(t, first.f1, 0)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		entries, err := os.ReadDir(dir)
+		assert.NilError(t, err)
+		if len(entries) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("abandoned ExternalSort iterator left temporary files")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,5 +1,12 @@
 package flinx
 
+import (
+	"golang.org/x/exp/constraints"
+
+	"github.com/kom0055/go-flinx/hashmap"
+	"github.com/kom0055/go-flinx/hashset"
+)
+
 // Iterator is an alias for function to iterate over data.
 type Iterator[T any] func() (item T, ok bool)
 
@@ -68,6 +75,36 @@ func FromMap[K comparable, V any](source map[K]V) Query[KeyValue[K, V]] {
 	}
 }
 
+// FromSet initializes a linq query with passed set, linq iterates over the
+// items of the set in its iteration order: unspecified for hashset.Any,
+// insertion order for hashset.Ordered and ascending order for hashset.Sorted.
+// The items are read from the set each time the query is iterated.
+func FromSet[K any](source hashset.Set[K]) Query[K] {
+	return Query[K]{
+		Iterate: func() Iterator[K] {
+			return FromSlice(source.List()).Iterate()
+		},
+	}
+}
+
+// FromEntries initializes a linq query with passed map of the hashmap package,
+// linq iterates over its entries as KeyValue, in the iteration order of the
+// map. A hashmap.MultiMap yields one KeyValue per value. The entries are read
+// from the map each time the query is iterated.
+func FromEntries[K comparable, V any](source hashmap.Entries[K, V]) Query[KeyValue[K, V]] {
+	return Query[KeyValue[K, V]]{
+		Iterate: func() Iterator[KeyValue[K, V]] {
+			entries := make([]KeyValue[K, V], 0, source.Len())
+			source.Range(func(key K, value V) bool {
+				entries = append(entries, KeyValue[K, V]{key, value})
+				return true
+			})
+
+			return FromSlice(entries).Iterate()
+		},
+	}
+}
+
 // FromChannel initializes a linq query with passed channel, linq iterates over
 // channel until it is closed.
 func FromChannel[T any](source <-chan T) Query[T] {
@@ -157,3 +194,145 @@ func Repeat[T any](value T, count int) Query[T] {
 		},
 	}
 }
+
+// RangeStep generates a sequence of numbers from start up to end, excluded,
+// each number being step after the previous one. The sequence is descending if
+// step is negative, and is empty if end is not after start in the direction of
+// step. RangeStep panics if step is zero or NaN.
+//
+// Floating point numbers are computed as start+index*step so that rounding
+// errors do not accumulate. Integer sequences stop before overflowing.
+func RangeStep[T constraints.Integer | constraints.Float](start, end, step T) Query[T] {
+	if !(step > 0 || step < 0) {
+		panic("flinx: RangeStep step must not be zero")
+	}
+
+	var half T = 1
+	half /= 2
+	floating := half != 0
+
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			index := 0
+			current := start
+			done := false
+
+			return func() (item T, ok bool) {
+				if done || step > 0 && current >= end || step < 0 && current <= end {
+					return
+				}
+
+				item = current
+
+				index++
+				if floating {
+					current = start + T(index)*step
+				} else {
+					current += step
+				}
+				if step > 0 && current <= item || step < 0 && current >= item {
+					done = true
+				}
+				return item, true
+			}
+		},
+	}
+}
+
+// Iterate generates an infinite sequence starting with seed, each element
+// being the result of next applied to the previous one. Use Take or TakeWhile
+// to bound it.
+func Iterate[T any](seed T, next func(T) T) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			current := seed
+			started := false
+
+			return func() (item T, ok bool) {
+				if started {
+					current = next(current)
+				}
+				started = true
+				return current, true
+			}
+		},
+	}
+}
+
+// Unfold generates a sequence from an initial state. Each time an element is
+// requested, f is called with the current state, and returns the element, the
+// next state and whether the element exists. The sequence ends the first time
+// f returns false.
+func Unfold[S, T any](state S, f func(S) (T, S, bool)) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			current := state
+			done := false
+
+			return func() (item T, ok bool) {
+				if done {
+					return
+				}
+
+				it, next, more := f(current)
+				if !more {
+					done = true
+					return
+				}
+
+				current = next
+				return it, true
+			}
+		},
+	}
+}
+
+// Generate generates an infinite sequence whose elements are the results of
+// successive calls to f. Use Take or TakeWhile to bound it.
+func Generate[T any](f func() T) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			return func() (item T, ok bool) {
+				return f(), true
+			}
+		},
+	}
+}
+
+// Cycle repeats the elements of a collection forever. The collection is
+// iterated again each time it is exhausted, and the sequence ends if an
+// iteration yields no element, so cycling an empty collection or a consumed
+// channel does not loop forever.
+func Cycle[T any](q Query[T]) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			next := q.Iterate()
+			empty := true
+			done := false
+
+			return func() (item T, ok bool) {
+				if done {
+					return
+				}
+
+				if item, ok = next(); ok {
+					empty = false
+					return
+				}
+				if empty {
+					done = true
+					return
+				}
+
+				next = q.Iterate()
+				empty = true
+				if item, ok = next(); ok {
+					empty = false
+				} else {
+					done = true
+				}
+				return
+			}
+		},
+	}
+}
@@ -1,9 +1,14 @@
 package flinx
 
 import (
+	"math"
 	"testing"
 
 	"gotest.tools/v3/assert"
+
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashmap"
+	"github.com/kom0055/go-flinx/hashset"
 )
 
 func TestFrom(t *testing.T) {
@@ -146,6 +151,43 @@ func TestFrom(t *testing.T) {
 
 }
 
+func TestFromSet(t *testing.T) {
+	s := hashset.NewOrdered(3, 1, 2)
+	q := FromSet[int](s)
+
+	if w := []int{3, 1, 2}; !ValidateQuery(q, w) {
+		t.Errorf("FromSet(%v)=%v expected %v", s.List(), ToSlice(q), w)
+	}
+	s.Insert(0)
+	if w := []int{3, 1, 2, 0}; !ValidateQuery(q, w) {
+		t.Errorf("FromSet(%v)=%v expected %v", s.List(), ToSlice(q), w)
+	}
+
+	sorted := hashset.NewSorted(generics.OrderedCompare[int], 3, 1, 2)
+	if w := []int{1, 2, 3}; !ValidateQuery(FromSet[int](sorted), w) {
+		t.Errorf("FromSet(%v)=%v expected %v", sorted.List(), ToSlice(FromSet[int](sorted)), w)
+	}
+
+	assert.Equal(t, Sum(FromSet[int](hashset.NewAny(1, 2, 3))), 6)
+}
+
+func TestFromEntries(t *testing.T) {
+	m := hashmap.NewOrderedMap[string, int]().Set("b", 1).Set("a", 2)
+	want := []KeyValue[string, int]{{"b", 1}, {"a", 2}}
+	if q := FromEntries[string, int](m); !ValidateQuery(q, want) {
+		t.Errorf("FromEntries()=%v expected %v", ToSlice(q), want)
+	}
+
+	mm := hashmap.NewMultiMap[string, int]().Put("x", 1, 2).Put("y", 3)
+	want = []KeyValue[string, int]{{"x", 1}, {"x", 2}, {"y", 3}}
+	if q := FromEntries[string, int](mm); !ValidateQuery(q, want) {
+		t.Errorf("FromEntries()=%v expected %v", ToSlice(q), want)
+	}
+
+	bm := hashmap.NewBiMap[string, int]().Put("x", 1).Put("y", 2)
+	assert.DeepEqual(t, ToMap(FromEntries[string, int](bm)), map[string]int{"x": 1, "y": 2})
+}
+
 func TestFromChannel(t *testing.T) {
 	c := make(chan int, 3)
 	c <- 10
@@ -229,3 +271,68 @@ func TestRepeat(t *testing.T) {
 		t.Errorf("Repeat(1, 5)=%v expected %v", ToSlice(q), w)
 	}
 }
+
+func TestRangeStep(t *testing.T) {
+	assert.DeepEqual(t, ToSlice(RangeStep(0, 10, 3)), []int{0, 3, 6, 9})
+	assert.DeepEqual(t, ToSlice(RangeStep(10, 0, -4)), []int{10, 6, 2})
+	assert.DeepEqual(t, ToSlice(RangeStep(0, 0, 1)), []int{})
+	assert.DeepEqual(t, ToSlice(RangeStep(0, 5, -1)), []int{})
+	assert.DeepEqual(t, ToSlice(RangeStep[int8](120, 127, 5)), []int8{120, 125})
+	assert.DeepEqual(t, ToSlice(RangeStep[uint8](250, 0, 1)), []uint8{})
+	assert.DeepEqual(t, ToSlice(RangeStep[int8](-120, -128, -5)), []int8{-120, -125})
+
+	assert.DeepEqual(t, ToSlice(RangeStep(0, 1, 0.25)), []float64{0, 0.25, 0.5, 0.75})
+	assert.DeepEqual(t, ToSlice(RangeStep(1, 0, -0.5)), []float64{1, 0.5})
+	assert.Equal(t, Count(RangeStep(0, 1, 0.1)), 10)
+
+	// RangeStep is infinite if end cannot be reached, and composes with Take.
+	assert.DeepEqual(t, ToSlice(Take(RangeStep(0, math.Inf(1), 2), 3)), []float64{0, 2, 4})
+
+	defer func() {
+		assert.Assert(t, recover() != nil)
+	}()
+	RangeStep(0, 1, 0)
+}
+
+func TestIterate(t *testing.T) {
+	powers := Iterate(1, func(i int) int { return i * 2 })
+
+	assert.DeepEqual(t, ToSlice(Take(powers, 5)), []int{1, 2, 4, 8, 16})
+	assert.DeepEqual(t, ToSlice(TakeWhile(func(i int) bool { return i < 100 })(powers)), []int{1, 2, 4, 8, 16, 32, 64})
+}
+
+func TestUnfold(t *testing.T) {
+	type fib struct{ a, b int }
+	fibonacci := Unfold(fib{0, 1}, func(s fib) (int, fib, bool) {
+		return s.a, fib{s.b, s.a + s.b}, true
+	})
+	assert.DeepEqual(t, ToSlice(Take(fibonacci, 8)), []int{0, 1, 1, 2, 3, 5, 8, 13})
+
+	digits := Unfold(1234, func(n int) (int, int, bool) {
+		return n % 10, n / 10, n > 0
+	})
+	assert.DeepEqual(t, ToSlice(digits), []int{4, 3, 2, 1})
+	assert.DeepEqual(t, ToSlice(digits), []int{4, 3, 2, 1})
+}
+
+func TestGenerate(t *testing.T) {
+	i := 0
+	counter := Generate(func() int {
+		i++
+		return i
+	})
+
+	assert.DeepEqual(t, ToSlice(Take(counter, 3)), []int{1, 2, 3})
+	assert.DeepEqual(t, ToSlice(Take(counter, 2)), []int{4, 5})
+}
+
+func TestCycle(t *testing.T) {
+	assert.DeepEqual(t, ToSlice(Take(Cycle(FromSlice([]int{1, 2, 3})), 7)), []int{1, 2, 3, 1, 2, 3, 1})
+	assert.DeepEqual(t, ToSlice(Cycle(FromSlice([]int{}))), []int{})
+
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+	close(c)
+	assert.DeepEqual(t, ToSlice(Cycle(FromChannel(c))), []int{1, 2})
+}
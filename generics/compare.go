@@ -1,23 +1,44 @@
 package generics
 
 import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/exp/constraints"
 )
 
+// NumericCompare compares two numbers, returning -1, 0 or 1. Floats are
+// compared without truncation, a NaN is considered less than any other value
+// and equal to another NaN, and -0.0 is equal to 0.0.
 func NumericCompare[T constraints.Float | constraints.Integer](v1, v2 T) int {
-	return int(int64(v1) - int64(v2))
+	return compareOrdered(v1, v2)
 }
 
+// OrderedCompare compares two ordered values, returning -1, 0 or 1. Floats are
+// compared like NumericCompare does.
 func OrderedCompare[T constraints.Ordered](t1, t2 T) int {
-	if t1 == t2 {
-		return 0
-	}
+	return compareOrdered(t1, t2)
+}
 
-	if t1 < t2 {
+func compareOrdered[T constraints.Ordered](t1, t2 T) int {
+	// x != x only holds for NaN.
+	nan1, nan2 := t1 != t1, t2 != t2
+	switch {
+	case nan1 && nan2:
+		return 0
+	case nan1:
+		return -1
+	case nan2:
+		return 1
+	case t1 < t2:
 		return -1
+	case t1 > t2:
+		return 1
+	default:
+		return 0
 	}
-	return 1
 }
 
 func BoolCompare(a, b bool) int {
@@ -31,6 +52,149 @@ func BoolCompare(a, b bool) int {
 	}
 }
 
+// TimeCompare compares two instants, returning -1 if t1 is before t2, 1 if it
+// is after t2 and 0 otherwise.
+func TimeCompare(t1, t2 time.Time) int {
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DurationCompare compares two durations, returning -1, 0 or 1.
+func DurationCompare(d1, d2 time.Duration) int {
+	return compareOrdered(d1, d2)
+}
+
+// CaseInsensitiveCompare compares two strings rune by rune, ignoring case.
+func CaseInsensitiveCompare(s1, s2 string) int {
+	for s1 != "" && s2 != "" {
+		r1, n1 := utf8.DecodeRuneInString(s1)
+		r2, n2 := utf8.DecodeRuneInString(s2)
+		if c := compareOrdered(unicode.ToLower(r1), unicode.ToLower(r2)); c != 0 {
+			return c
+		}
+		s1, s2 = s1[n1:], s2[n2:]
+	}
+
+	return compareOrdered(len(s1), len(s2))
+}
+
+// NaturalCompare compares two strings in natural order, sequences of decimal
+// digits being compared by their numeric value, so "file9" is before
+// "file10". Numbers with equal values are ordered by their number of leading
+// zeros, fewer first, and other runes are compared by their code point.
+func NaturalCompare(s1, s2 string) int {
+	zeros := 0
+	for s1 != "" && s2 != "" {
+		if isDigit(s1[0]) && isDigit(s2[0]) {
+			d1, d2 := digitsPrefix(s1), digitsPrefix(s2)
+			v1, v2 := trimZeros(s1[:d1]), trimZeros(s2[:d2])
+			if c := compareOrdered(len(v1), len(v2)); c != 0 {
+				return c
+			}
+			if c := compareOrdered(v1, v2); c != 0 {
+				return c
+			}
+			if zeros == 0 {
+				zeros = compareOrdered(d1, d2)
+			}
+			s1, s2 = s1[d1:], s2[d2:]
+			continue
+		}
+
+		r1, n1 := utf8.DecodeRuneInString(s1)
+		r2, n2 := utf8.DecodeRuneInString(s2)
+		if c := compareOrdered(r1, r2); c != 0 {
+			return c
+		}
+		s1, s2 = s1[n1:], s2[n2:]
+	}
+
+	if c := compareOrdered(len(s1), len(s2)); c != 0 {
+		return c
+	}
+	return zeros
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func digitsPrefix(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+func trimZeros(digits string) string {
+	i := 0
+	for i < len(digits)-1 && digits[i] == '0' {
+		i++
+	}
+	return digits[i:]
+}
+
+// Reverse returns a comparer ordering values in the opposite order of compare.
+func Reverse[T any](compare func(T, T) int) func(T, T) int {
+	return func(t1, t2 T) int {
+		return compare(t2, t1)
+	}
+}
+
+// ComparingBy returns a comparer ordering values by the key extracted by
+// selector, according to compare.
+func ComparingBy[T, V any](selector func(T) V, compare func(V, V) int) func(T, T) int {
+	return func(t1, t2 T) int {
+		return compare(selector(t1), selector(t2))
+	}
+}
+
+// ThenComparing returns a comparer ordering values by the first of compares,
+// and values equal according to it by the next ones, in turn.
+func ThenComparing[T any](compares ...func(T, T) int) func(T, T) int {
+	return func(t1, t2 T) int {
+		for _, compare := range compares {
+			if c := compare(t1, t2); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// NilFirst returns a comparer of pointers, ordering nil before any other
+// pointer and the pointed values according to compare.
+func NilFirst[T any](compare func(T, T) int) func(*T, *T) int {
+	return func(p1, p2 *T) int {
+		switch {
+		case p1 == nil && p2 == nil:
+			return 0
+		case p1 == nil:
+			return -1
+		case p2 == nil:
+			return 1
+		default:
+			return compare(*p1, *p2)
+		}
+	}
+}
+
+// NilLast returns a comparer of pointers, ordering nil after any other pointer
+// and the pointed values according to compare.
+func NilLast[T any](compare func(T, T) int) func(*T, *T) int {
+	nilFirst := NilFirst(Reverse(compare))
+	return func(p1, p2 *T) int {
+		return nilFirst(p2, p1)
+	}
+}
+
 func Equal(x, y any) bool {
 	return cmp.Equal(x, y)
 }
@@ -0,0 +1,125 @@
+package generics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNumericCompare(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		v1, v2 float64
+		want   int
+	}{
+		{0.2, 0.7, -1},
+		{0.7, 0.2, 1},
+		{0.5, 0.5, 0},
+		{math.Copysign(0, -1), 0, 0},
+		{nan, nan, 0},
+		{nan, math.Inf(-1), -1},
+		{math.Inf(-1), nan, 1},
+		{math.Inf(1), math.MaxFloat64, 1},
+	}
+
+	for _, test := range tests {
+		if r := NumericCompare(test.v1, test.v2); r != test.want {
+			t.Errorf("NumericCompare(%v, %v)=%v expected %v", test.v1, test.v2, r, test.want)
+		}
+		if r := OrderedCompare(test.v1, test.v2); r != test.want {
+			t.Errorf("OrderedCompare(%v, %v)=%v expected %v", test.v1, test.v2, r, test.want)
+		}
+	}
+
+	assert.Equal(t, NumericCompare[int64](math.MinInt64, math.MaxInt64), -1)
+	assert.Equal(t, NumericCompare[uint64](math.MaxUint64, 0), 1)
+	assert.Equal(t, NumericCompare[int8](-128, 127), -1)
+}
+
+func TestTimeCompare(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, TimeCompare(now, now.Add(time.Nanosecond)), -1)
+	assert.Equal(t, TimeCompare(now.Add(time.Second), now), 1)
+	assert.Equal(t, TimeCompare(now, now.UTC()), 0)
+	assert.Equal(t, DurationCompare(time.Second, time.Minute), -1)
+	assert.Equal(t, DurationCompare(time.Hour, time.Minute), 1)
+}
+
+func TestCaseInsensitiveCompare(t *testing.T) {
+	assert.Equal(t, CaseInsensitiveCompare("Hello", "hELLO"), 0)
+	assert.Equal(t, CaseInsensitiveCompare("apple", "Banana"), -1)
+	assert.Equal(t, CaseInsensitiveCompare("ÉCOLE", "école"), 0)
+	assert.Equal(t, CaseInsensitiveCompare("abc", "AB"), 1)
+	assert.Equal(t, CaseInsensitiveCompare("", "a"), -1)
+}
+
+func TestNaturalCompare(t *testing.T) {
+	input := []string{"file10", "file9", "file1", "file010", "file", "file2b", "file2a", "a100", "a20b3", "a20b10"}
+	want := []string{"a20b3", "a20b10", "a100", "file", "file1", "file2a", "file2b", "file9", "file10", "file010"}
+
+	sort.Slice(input, func(i, j int) bool {
+		return NaturalCompare(input[i], input[j]) < 0
+	})
+	assert.DeepEqual(t, input, want)
+	assert.Equal(t, NaturalCompare("x01", "x1"), 1)
+	assert.Equal(t, NaturalCompare("x1", "x1"), 0)
+}
+
+func TestComparerCombinators(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+	users := []user{{"bob", 30}, {"alice", 25}, {"carol", 30}, {"dave", 25}}
+
+	byAge := ComparingBy(func(u user) int { return u.Age }, OrderedCompare[int])
+	byName := ComparingBy(func(u user) string { return u.Name }, OrderedCompare[string])
+	sort.Slice(users, func(i, j int) bool {
+		return ThenComparing(Reverse(byAge), byName)(users[i], users[j]) < 0
+	})
+	assert.DeepEqual(t, users, []user{{"bob", 30}, {"carol", 30}, {"alice", 25}, {"dave", 25}})
+
+	assert.Equal(t, ThenComparing[int]()(1, 2), 0)
+	assert.Equal(t, Reverse(strings.Compare)("a", "b"), 1)
+}
+
+func TestReverse(t *testing.T) {
+	assert.Equal(t, Reverse(strings.Compare)("b", "a"), -1)
+	assert.Equal(t, Reverse(OrderedCompare[int])(2, 2), 0)
+
+	input := []int{5, 12, 3, 21, 14, 1}
+	sort.SliceStable(input, func(i, j int) bool {
+		return Reverse(OrderedCompare[int])(input[i], input[j]) < 0
+	})
+	assert.DeepEqual(t, input, []int{21, 14, 12, 5, 3, 1})
+
+	floats := []float64{1, math.NaN(), 2}
+	sort.SliceStable(floats, func(i, j int) bool {
+		return Reverse(NumericCompare[float64])(floats[i], floats[j]) < 0
+	})
+	assert.Equal(t, floats[0], 2.0)
+	assert.Equal(t, floats[1], 1.0)
+	assert.Assert(t, math.IsNaN(floats[2]))
+}
+
+func TestNilCompare(t *testing.T) {
+	one, two := Pointer(1), Pointer(2)
+
+	nilFirst := NilFirst(OrderedCompare[int])
+	assert.Equal(t, nilFirst(nil, one), -1)
+	assert.Equal(t, nilFirst(one, nil), 1)
+	assert.Equal(t, nilFirst(nil, nil), 0)
+	assert.Equal(t, nilFirst(one, two), -1)
+
+	nilLast := NilLast(OrderedCompare[int])
+	assert.Equal(t, nilLast(nil, one), 1)
+	assert.Equal(t, nilLast(one, nil), -1)
+	assert.Equal(t, nilLast(nil, nil), 0)
+	assert.Equal(t, nilLast(one, two), -1)
+	assert.Equal(t, nilLast(two, one), 1)
+}
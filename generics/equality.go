@@ -0,0 +1,68 @@
+package generics
+
+import (
+	"hash/fnv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EqualityComparer defines how to compare values for equality, for operators
+// that need to find equal values among values that are not comparable with ==,
+// or whose equality is not the Go one.
+//
+// Equal values must have the same hash.
+type EqualityComparer[T any] interface {
+	Equals(t1, t2 T) bool
+	Hash(t T) uint64
+}
+
+type equalityComparer[T any] struct {
+	equals func(T, T) bool
+	hash   func(T) uint64
+}
+
+func (c equalityComparer[T]) Equals(t1, t2 T) bool {
+	return c.equals(t1, t2)
+}
+
+func (c equalityComparer[T]) Hash(t T) uint64 {
+	return c.hash(t)
+}
+
+// NewEqualityComparer returns an EqualityComparer using the given functions.
+func NewEqualityComparer[T any](equals func(T, T) bool, hash func(T) uint64) EqualityComparer[T] {
+	return equalityComparer[T]{equals: equals, hash: hash}
+}
+
+// EqualityComparerBy returns an EqualityComparer considering values equal when
+// the keys extracted by selector are equal.
+func EqualityComparerBy[T any, K comparable](selector func(T) K, hash func(K) uint64) EqualityComparer[T] {
+	return NewEqualityComparer(func(t1, t2 T) bool {
+		return selector(t1) == selector(t2)
+	}, func(t T) uint64 {
+		return hash(selector(t))
+	})
+}
+
+// StringHash hashes a string with FNV-1a.
+func StringHash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// CaseInsensitiveEqualityComparer returns an EqualityComparer of strings
+// ignoring case, consistent with CaseInsensitiveCompare.
+func CaseInsensitiveEqualityComparer() EqualityComparer[string] {
+	return NewEqualityComparer(func(s1, s2 string) bool {
+		return CaseInsensitiveCompare(s1, s2) == 0
+	}, func(s string) uint64 {
+		h := fnv.New64a()
+		var buf [utf8.UTFMax]byte
+		for _, r := range s {
+			n := utf8.EncodeRune(buf[:], unicode.ToLower(r))
+			_, _ = h.Write(buf[:n])
+		}
+		return h.Sum64()
+	})
+}
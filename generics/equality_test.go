@@ -0,0 +1,28 @@
+package generics
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCaseInsensitiveEqualityComparer(t *testing.T) {
+	c := CaseInsensitiveEqualityComparer()
+
+	assert.Assert(t, c.Equals("Straße", "STRAßE"))
+	assert.Equal(t, c.Hash("Straße"), c.Hash("STRAßE"))
+	assert.Assert(t, !c.Equals("go", "gopher"))
+}
+
+func TestEqualityComparerBy(t *testing.T) {
+	type user struct {
+		ID   string
+		Tags []string
+	}
+	c := EqualityComparerBy(func(u user) string { return u.ID }, StringHash)
+
+	u1, u2 := user{"1", []string{"a"}}, user{"1", []string{"b"}}
+	assert.Assert(t, c.Equals(u1, u2))
+	assert.Equal(t, c.Hash(u1), c.Hash(u2))
+	assert.Assert(t, !c.Equals(u1, user{ID: "2"}))
+}
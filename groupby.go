@@ -1,5 +1,7 @@
 package flinx
 
+import "sort"
+
 // Group is a type that is used to store the result of GroupBy method.
 type Group[K, V any] struct {
 	Key   K
@@ -17,37 +19,82 @@ func (g Group[K, V]) GetGroup() []V {
 // GroupBy method groups the elements of a collection according to a specified
 // key selector function and projects the elements for each group by using a
 // specified function.
+//
+// Groups are emitted in the order their key first appears in the collection,
+// and the elements of each group keep their order in the collection.
 func GroupBy[T any, K comparable, V any](keySelector func(T) K,
 	elementSelector func(T) V) func(q Query[T]) Query[Group[K, V]] {
+	toLookup := ToLookup(keySelector, elementSelector)
 	return func(q Query[T]) Query[Group[K, V]] {
 		return Query[Group[K, V]]{
-			func() Iterator[Group[K, V]] {
-				next := q.Iterate()
-				set := map[K][]V{}
+			Iterate: func() Iterator[Group[K, V]] {
+				return toLookup(q).Groups().Iterate()
+			},
+		}
+	}
 
-				for item, ok := next(); ok; item, ok = next() {
-					key := keySelector(item)
-					set[key] = append(set[key], elementSelector(item))
-				}
+}
 
-				length := len(set)
-				idx := 0
-				groups := make([]Group[K, V], length)
-				for k, v := range set {
-					groups[idx] = Group[K, V]{k, v}
-					idx++
-				}
+// GroupByOrdered method groups the elements of a collection like GroupBy does,
+// but emits the groups in ascending order of their key according to compare.
+// Groups with equal keys according to compare keep the order their key first
+// appears in the collection.
+func GroupByOrdered[T any, K comparable, V any](compare func(K, K) int, keySelector func(T) K,
+	elementSelector func(T) V) func(q Query[T]) Query[Group[K, V]] {
+	toLookup := ToLookup(keySelector, elementSelector)
+	return func(q Query[T]) Query[Group[K, V]] {
+		return Query[Group[K, V]]{
+			Iterate: func() Iterator[Group[K, V]] {
+				groups := ToSlice(toLookup(q).Groups())
+				sort.SliceStable(groups, func(i, j int) bool {
+					return compare(groups[i].Key, groups[j].Key) < 0
+				})
+
+				return FromSlice(groups).Iterate()
+			},
+		}
+	}
 
-				index := 0
+}
+
+// GroupAdjacent method groups the consecutive elements of a collection that
+// have the same key according to a specified key selector function, and
+// projects the elements for each group by using a specified function. A new
+// group is started each time the key changes, so a key appears in several
+// groups if its elements are not adjacent.
+//
+// Unlike GroupBy, GroupAdjacent only buffers the elements of the current
+// group, and emits it as soon as an element with another key is read, so it
+// can be used on unbounded sources whose elements are already grouped, such as
+// a channel of sorted records.
+func GroupAdjacent[T any, K comparable, V any](keySelector func(T) K,
+	elementSelector func(T) V) func(q Query[T]) Query[Group[K, V]] {
+	return func(q Query[T]) Query[Group[K, V]] {
+		return Query[Group[K, V]]{
+			Iterate: func() Iterator[Group[K, V]] {
+				next := q.Iterate()
+				var pending T
+				started, hasPending := false, false
 
 				return func() (item Group[K, V], ok bool) {
-					ok = index < length
-					if ok {
-						item = groups[index]
-						index++
+					if !started {
+						started = true
+						pending, hasPending = next()
+					}
+					if !hasPending {
+						return
+					}
+
+					item.Key = keySelector(pending)
+					item.Group = []V{elementSelector(pending)}
+					for pending, hasPending = next(); hasPending; pending, hasPending = next() {
+						if keySelector(pending) != item.Key {
+							break
+						}
+						item.Group = append(item.Group, elementSelector(pending))
 					}
 
-					return
+					return item, true
 				}
 			},
 		}
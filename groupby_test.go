@@ -3,6 +3,8 @@ package flinx
 import (
 	"reflect"
 	"testing"
+
+	"github.com/kom0055/go-flinx/generics"
 )
 
 func TestGroupBy(t *testing.T) {
@@ -41,3 +43,83 @@ func TestGroupBy(t *testing.T) {
 		t.Errorf("From(%v).GroupBy()=%v", input, ToSlice(q))
 	}
 }
+
+func TestGroupByOrder(t *testing.T) {
+	input := []string{"b1", "a1", "c1", "a2", "b2", "a3"}
+	key := func(s string) byte {
+		return s[0]
+	}
+
+	want := []Group[byte, string]{
+		{'b', []string{"b1", "b2"}},
+		{'a', []string{"a1", "a2", "a3"}},
+		{'c', []string{"c1"}},
+	}
+	for i := 0; i < 10; i++ {
+		if r := ToSlice(GroupBy(key, Self[string])(FromSlice(input))); !reflect.DeepEqual(r, want) {
+			t.Fatalf("From(%v).GroupBy()=%v expected %v", input, r, want)
+		}
+	}
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	input := []int{5, 12, 3, 21, 14, 1}
+	tens := func(i int) int {
+		return i / 10
+	}
+
+	want := []Group[int, int]{
+		{0, []int{5, 3, 1}},
+		{1, []int{12, 14}},
+		{2, []int{21}},
+	}
+	if r := ToSlice(GroupByOrdered(generics.OrderedCompare[int], tens, Self[int])(FromSlice(input))); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).GroupByOrdered()=%v expected %v", input, r, want)
+	}
+
+	parity := func(i int) int {
+		return i % 2
+	}
+	want = []Group[int, int]{
+		{1, []int{5, 3, 21, 1}},
+		{0, []int{12, 14}},
+	}
+	if r := ToSlice(GroupByOrdered(func(a, b int) int {
+		return b - a
+	}, parity, Self[int])(FromSlice(input))); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).GroupByOrdered()=%v expected %v", input, r, want)
+	}
+}
+
+func TestGroupAdjacent(t *testing.T) {
+	input := []string{"a1", "a2", "b1", "a3", "c1", "c2"}
+	key := func(s string) byte {
+		return s[0]
+	}
+
+	want := []Group[byte, string]{
+		{'a', []string{"a1", "a2"}},
+		{'b', []string{"b1"}},
+		{'a', []string{"a3"}},
+		{'c', []string{"c1", "c2"}},
+	}
+	if r := ToSlice(GroupAdjacent(key, Self[string])(FromSlice(input))); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).GroupAdjacent()=%v expected %v", input, r, want)
+	}
+	if r := ToSlice(GroupAdjacent(key, Self[string])(FromSlice([]string{}))); len(r) != 0 {
+		t.Errorf("From([]).GroupAdjacent()=%v expected empty", r)
+	}
+
+	c := make(chan int)
+	go func() {
+		for _, i := range []int{1, 3, 2} {
+			c <- i
+		}
+	}()
+	parity := func(i int) int {
+		return i % 2
+	}
+	if r, ok := First(GroupAdjacent(parity, Self[int])(FromChannel(c))); !ok || !reflect.DeepEqual(r, Group[int, int]{1, []int{1, 3}}) {
+		t.Errorf("FromChannel().GroupAdjacent().First()=%v expected %v", r, Group[int, int]{1, []int{1, 3}})
+	}
+}
@@ -24,13 +24,7 @@ func GroupJoin[T, V, O any, K comparable](
 		return Query[O]{
 			Iterate: func() Iterator[O] {
 				outernext := q.Iterate()
-				innernext := inner.Iterate()
-
-				innerLookup := make(map[K][]V)
-				for innerItem, ok := innernext(); ok; innerItem, ok = innernext() {
-					innerKey := innerKeySelector(innerItem)
-					innerLookup[innerKey] = append(innerLookup[innerKey], innerItem)
-				}
+				innerLookup := ToLookup(innerKeySelector, Self[V])(inner)
 
 				return func() (item O, ok bool) {
 					var tItem T
@@ -38,10 +32,10 @@ func GroupJoin[T, V, O any, K comparable](
 						return
 					}
 
-					if group, has := innerLookup[outerKeySelector(tItem)]; !has {
+					if group, has := innerLookup.groups[outerKeySelector(tItem)]; !has {
 						item = resultSelector(tItem, []V{})
 					} else {
-						item = resultSelector(tItem, group)
+						item = resultSelector(tItem, group[:len(group):len(group)])
 					}
 
 					return
@@ -0,0 +1,87 @@
+package hashmap
+
+// BiMap is a bidirectional map, in which values are unique as well as keys, so
+// keys can be looked up by value. Its iteration order is unspecified.
+type BiMap[K, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+}
+
+func NewBiMap[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  map[K]V{},
+		backward: map[V]K{},
+	}
+}
+
+// Put maps key to value, removing any previous mapping of key or of value.
+func (m *BiMap[K, V]) Put(key K, value V) *BiMap[K, V] {
+	m.DeleteKey(key)
+	m.DeleteValue(value)
+	m.forward[key] = value
+	m.backward[value] = key
+	return m
+}
+
+// Get returns the value mapped to key.
+func (m *BiMap[K, V]) Get(key K) (V, bool) {
+	value, has := m.forward[key]
+	return value, has
+}
+
+// GetKey returns the key mapped to value.
+func (m *BiMap[K, V]) GetKey(value V) (K, bool) {
+	key, has := m.backward[value]
+	return key, has
+}
+
+func (m *BiMap[K, V]) HasKey(key K) bool {
+	_, has := m.forward[key]
+	return has
+}
+
+func (m *BiMap[K, V]) HasValue(value V) bool {
+	_, has := m.backward[value]
+	return has
+}
+
+// DeleteKey removes the mapping of key.
+func (m *BiMap[K, V]) DeleteKey(key K) *BiMap[K, V] {
+	if value, has := m.forward[key]; has {
+		delete(m.forward, key)
+		delete(m.backward, value)
+	}
+	return m
+}
+
+// DeleteValue removes the mapping of value.
+func (m *BiMap[K, V]) DeleteValue(value V) *BiMap[K, V] {
+	if key, has := m.backward[value]; has {
+		delete(m.backward, value)
+		delete(m.forward, key)
+	}
+	return m
+}
+
+// Inverse returns a copy of the map with keys and values swapped.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	inverse := NewBiMap[V, K]()
+	for key, value := range m.forward {
+		inverse.forward[value] = key
+		inverse.backward[key] = value
+	}
+	return inverse
+}
+
+func (m *BiMap[K, V]) Len() int {
+	return len(m.forward)
+}
+
+// Range calls f for each key and its value.
+func (m *BiMap[K, V]) Range(f func(key K, value V) bool) {
+	for key, value := range m.forward {
+		if !f(key, value) {
+			return
+		}
+	}
+}
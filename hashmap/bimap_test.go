@@ -0,0 +1,47 @@
+package hashmap
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBiMap(t *testing.T) {
+	m := NewBiMap[string, int]()
+	m.Put("one", 1).Put("two", 2)
+
+	v, ok := m.Get("one")
+	assert.Assert(t, ok)
+	assert.Equal(t, v, 1)
+	k, ok := m.GetKey(2)
+	assert.Assert(t, ok)
+	assert.Equal(t, k, "two")
+
+	m.Put("uno", 1)
+	assert.Assert(t, !m.HasKey("one"))
+	k, _ = m.GetKey(1)
+	assert.Equal(t, k, "uno")
+
+	m.Put("two", 3)
+	assert.Assert(t, !m.HasValue(2))
+	assert.Equal(t, m.Len(), 2)
+
+	inverse := m.Inverse()
+	k, ok = inverse.Get(3)
+	assert.Assert(t, ok)
+	assert.Equal(t, k, "two")
+	assert.Equal(t, inverse.Len(), 2)
+
+	m.DeleteValue(3).DeleteKey("uno")
+	assert.Equal(t, m.Len(), 0)
+	_, ok = m.Get("uno")
+	assert.Assert(t, !ok)
+	assert.Equal(t, inverse.Len(), 2)
+
+	count := 0
+	inverse.Range(func(key int, value string) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, count, 1)
+}
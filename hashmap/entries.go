@@ -0,0 +1,9 @@
+package hashmap
+
+// Entries is implemented by every map of this package. Range calls f for each
+// key and value of the map, in the iteration order of the map, until f returns
+// false.
+type Entries[K, V any] interface {
+	Range(f func(key K, value V) bool)
+	Len() int
+}
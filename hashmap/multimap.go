@@ -0,0 +1,90 @@
+package hashmap
+
+// MultiMap maps each key to one or more values. Keys are kept in the order
+// they were first inserted, and the values of a key in the order they were
+// put.
+type MultiMap[K comparable, V any] struct {
+	keys   []K
+	values map[K][]V
+	count  int
+}
+
+func NewMultiMap[K comparable, V any]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{values: map[K][]V{}}
+}
+
+// Put appends values to the values of key.
+func (m *MultiMap[K, V]) Put(key K, values ...V) *MultiMap[K, V] {
+	if len(values) == 0 {
+		return m
+	}
+
+	current, has := m.values[key]
+	if !has {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = append(current, values...)
+	m.count += len(values)
+	return m
+}
+
+// Get returns the values of key, or nil if key is not in the map.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	return m.values[key]
+}
+
+func (m *MultiMap[K, V]) Has(key K) bool {
+	_, has := m.values[key]
+	return has
+}
+
+// Delete removes keys and all their values.
+func (m *MultiMap[K, V]) Delete(keys ...K) *MultiMap[K, V] {
+	removed := false
+	for _, key := range keys {
+		if values, has := m.values[key]; has {
+			m.count -= len(values)
+			delete(m.values, key)
+			removed = true
+		}
+	}
+
+	if removed {
+		kept := m.keys[:0]
+		for _, key := range m.keys {
+			if _, has := m.values[key]; has {
+				kept = append(kept, key)
+			}
+		}
+		m.keys = kept
+	}
+	return m
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *MultiMap[K, V]) Keys() []K {
+	res := make([]K, len(m.keys))
+	copy(res, m.keys)
+	return res
+}
+
+// Len returns the number of keys of the map.
+func (m *MultiMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// ValueCount returns the number of values of the map, across all keys.
+func (m *MultiMap[K, V]) ValueCount() int {
+	return m.count
+}
+
+// Range calls f for each key and each of its values.
+func (m *MultiMap[K, V]) Range(f func(key K, value V) bool) {
+	for _, key := range m.keys {
+		for _, value := range m.values[key] {
+			if !f(key, value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package hashmap
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMultiMap(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	m.Put("b", 1).Put("a", 2, 3).Put("b", 4).Put("c")
+
+	assert.DeepEqual(t, m.Keys(), []string{"b", "a"})
+	assert.DeepEqual(t, m.Get("b"), []int{1, 4})
+	assert.Assert(t, m.Get("c") == nil)
+	assert.Assert(t, m.Has("a"))
+	assert.Assert(t, !m.Has("c"))
+	assert.Equal(t, m.Len(), 2)
+	assert.Equal(t, m.ValueCount(), 4)
+
+	var entries []string
+	m.Range(func(key string, value int) bool {
+		entries = append(entries, key+string(rune('0'+value)))
+		return len(entries) < 3
+	})
+	assert.DeepEqual(t, entries, []string{"b1", "b4", "a2"})
+
+	m.Delete("b", "z")
+	assert.DeepEqual(t, m.Keys(), []string{"a"})
+	assert.Equal(t, m.ValueCount(), 2)
+}
@@ -0,0 +1,99 @@
+package hashmap
+
+// OrderedMap is a map remembering the order in which its keys were inserted.
+// Setting the value of a key that is already in the map doesn't change its
+// position. An OrderedMap must be created with NewOrderedMap and must not be
+// copied.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedEntry[K, V]
+	// head is a sentinel of the circular list of entries, head.next being the
+	// oldest entry.
+	head orderedEntry[K, V]
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedEntry[K, V]
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{entries: map[K]*orderedEntry[K, V]{}}
+	m.head.prev, m.head.next = &m.head, &m.head
+	return m
+}
+
+// Set maps key to value.
+func (m *OrderedMap[K, V]) Set(key K, value V) *OrderedMap[K, V] {
+	if e, has := m.entries[key]; has {
+		e.value = value
+		return m
+	}
+
+	e := &orderedEntry[K, V]{key: key, value: value, prev: m.head.prev, next: &m.head}
+	m.head.prev.next = e
+	m.head.prev = e
+	m.entries[key] = e
+	return m
+}
+
+// Get returns the value mapped to key.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, has := m.entries[key]; has {
+		return e.value, true
+	}
+
+	var zeroValue V
+	return zeroValue, false
+}
+
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, has := m.entries[key]
+	return has
+}
+
+// Delete removes the mappings of keys.
+func (m *OrderedMap[K, V]) Delete(keys ...K) *OrderedMap[K, V] {
+	for _, key := range keys {
+		e, has := m.entries[key]
+		if !has {
+			continue
+		}
+
+		e.prev.next = e.next
+		e.next.prev = e.prev
+		delete(m.entries, key)
+	}
+	return m
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	res := make([]K, 0, len(m.entries))
+	for e := m.head.next; e != &m.head; e = e.next {
+		res = append(res, e.key)
+	}
+	return res
+}
+
+// Values returns the values of the map in the insertion order of their keys.
+func (m *OrderedMap[K, V]) Values() []V {
+	res := make([]V, 0, len(m.entries))
+	for e := m.head.next; e != &m.head; e = e.next {
+		res = append(res, e.value)
+	}
+	return res
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Range calls f for each key and its value, in insertion order.
+func (m *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
+	for e := m.head.next; e != &m.head; e = e.next {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
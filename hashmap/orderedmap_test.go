@@ -0,0 +1,34 @@
+package hashmap
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 1).Set("a", 2).Set("b", 3).Set("a", 4)
+
+	assert.DeepEqual(t, m.Keys(), []string{"c", "a", "b"})
+	assert.DeepEqual(t, m.Values(), []int{1, 4, 3})
+	v, ok := m.Get("a")
+	assert.Assert(t, ok)
+	assert.Equal(t, v, 4)
+	_, ok = m.Get("z")
+	assert.Assert(t, !ok)
+	assert.Equal(t, m.Len(), 3)
+
+	m.Delete("c", "z").Set("c", 5)
+	assert.DeepEqual(t, m.Keys(), []string{"a", "b", "c"})
+	assert.Assert(t, m.Has("c"))
+
+	var keys []string
+	m.Range(func(key string, value int) bool {
+		keys = append(keys, key)
+		m.Delete(key)
+		return true
+	})
+	assert.DeepEqual(t, keys, []string{"a", "b", "c"})
+	assert.Equal(t, m.Len(), 0)
+}
@@ -5,6 +5,14 @@ type (
 	Any[K comparable] map[K]Empty
 )
 
+// Set is implemented by every set of this package. List returns the items of
+// the set, in the iteration order of the set.
+type Set[K any] interface {
+	Has(item K) bool
+	Len() int
+	List() []K
+}
+
 func NewAny[K comparable](items ...K) Any[K] {
 	ss := Any[K]{}
 	ss.Insert(items...)
@@ -96,6 +104,17 @@ func (s Any[K]) Intersection(s2 Any[K]) Any[K] {
 	return result
 }
 
+// SymmetricDifference returns the items that are in exactly one of s and s2.
+func (s Any[K]) SymmetricDifference(s2 Any[K]) Any[K] {
+	result := s.Difference(s2)
+	for key := range s2 {
+		if !s.Has(key) {
+			result.Insert(key)
+		}
+	}
+	return result
+}
+
 // IsSuperset returns true if and only if s1 is a superset of s2.
 func (s Any[K]) IsSuperset(s2 Any[K]) bool {
 	for item := range s2 {
@@ -106,10 +125,23 @@ func (s Any[K]) IsSuperset(s2 Any[K]) bool {
 	return true
 }
 
+// IsSubset returns true if and only if s1 is a subset of s2.
+func (s Any[K]) IsSubset(s2 Any[K]) bool {
+	return s2.IsSuperset(s)
+}
+
 func (s Any[K]) Equal(s2 Any[K]) bool {
 	return len(s) == len(s2) && s.IsSuperset(s2)
 }
 
+func (s Any[K]) Clone() Any[K] {
+	result := make(Any[K], len(s))
+	for key := range s {
+		result.Insert(key)
+	}
+	return result
+}
+
 func (s Any[K]) List() []K {
 	res := make([]K, 0, len(s))
 	for key := range s {
@@ -0,0 +1,38 @@
+package hashset
+
+import (
+	"sort"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func sortedList(s Any[int]) []int {
+	l := s.List()
+	sort.Ints(l)
+	return l
+}
+
+func TestAnySymmetricDifference(t *testing.T) {
+	s := NewAny(1, 2, 3)
+	s2 := NewAny(3, 4)
+
+	assert.DeepEqual(t, sortedList(s.SymmetricDifference(s2)), []int{1, 2, 4})
+	assert.DeepEqual(t, sortedList(s2.SymmetricDifference(s)), []int{1, 2, 4})
+	assert.Equal(t, s.SymmetricDifference(s).Len(), 0)
+}
+
+func TestAnyIsSubset(t *testing.T) {
+	assert.Assert(t, NewAny(1, 2).IsSubset(NewAny(1, 2, 3)))
+	assert.Assert(t, NewAny[int]().IsSubset(NewAny(1)))
+	assert.Assert(t, !NewAny(1, 4).IsSubset(NewAny(1, 2, 3)))
+}
+
+func TestAnyClone(t *testing.T) {
+	s := NewAny(1, 2)
+	c := s.Clone()
+	c.Insert(3)
+
+	assert.DeepEqual(t, sortedList(s), []int{1, 2})
+	assert.DeepEqual(t, sortedList(c), []int{1, 2, 3})
+}
@@ -0,0 +1,171 @@
+package hashset
+
+// Ordered is a set remembering the order in which its items were inserted.
+// Inserting an item that is already in the set doesn't change its position.
+// An Ordered must be created with NewOrdered and must not be copied.
+type Ordered[K comparable] struct {
+	entries map[K]*orderedEntry[K]
+	// head is a sentinel of the circular list of entries, head.next being the
+	// oldest entry.
+	head orderedEntry[K]
+}
+
+type orderedEntry[K comparable] struct {
+	key        K
+	prev, next *orderedEntry[K]
+}
+
+func NewOrdered[K comparable](items ...K) *Ordered[K] {
+	s := &Ordered[K]{entries: map[K]*orderedEntry[K]{}}
+	s.head.prev, s.head.next = &s.head, &s.head
+	s.Insert(items...)
+	return s
+}
+
+func (s *Ordered[K]) Insert(items ...K) *Ordered[K] {
+	for _, item := range items {
+		if _, has := s.entries[item]; has {
+			continue
+		}
+
+		e := &orderedEntry[K]{key: item, prev: s.head.prev, next: &s.head}
+		s.head.prev.next = e
+		s.head.prev = e
+		s.entries[item] = e
+	}
+	return s
+}
+
+func (s *Ordered[K]) Delete(items ...K) *Ordered[K] {
+	for _, item := range items {
+		e, has := s.entries[item]
+		if !has {
+			continue
+		}
+
+		e.prev.next = e.next
+		e.next.prev = e.prev
+		delete(s.entries, item)
+	}
+	return s
+}
+
+func (s *Ordered[K]) Has(item K) bool {
+	_, contained := s.entries[item]
+	return contained
+}
+
+func (s *Ordered[K]) HasAll(items ...K) bool {
+	for _, item := range items {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Ordered[K]) HasAny(items ...K) bool {
+	for _, item := range items {
+		if s.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Difference returns the items of s that are not in s2, in the order of s.
+func (s *Ordered[K]) Difference(s2 Set[K]) *Ordered[K] {
+	result := NewOrdered[K]()
+	for e := s.head.next; e != &s.head; e = e.next {
+		if !s2.Has(e.key) {
+			result.Insert(e.key)
+		}
+	}
+	return result
+}
+
+// Union returns the items of s followed by the items of s2 that are not in s,
+// in their respective order.
+func (s *Ordered[K]) Union(s2 Set[K]) *Ordered[K] {
+	result := s.Clone()
+	result.Insert(s2.List()...)
+	return result
+}
+
+// Intersection returns the items of s that are also in s2, in the order of s.
+func (s *Ordered[K]) Intersection(s2 Set[K]) *Ordered[K] {
+	result := NewOrdered[K]()
+	for e := s.head.next; e != &s.head; e = e.next {
+		if s2.Has(e.key) {
+			result.Insert(e.key)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns the items of s that are not in s2, followed by
+// the items of s2 that are not in s, in their respective order.
+func (s *Ordered[K]) SymmetricDifference(s2 Set[K]) *Ordered[K] {
+	result := s.Difference(s2)
+	for _, item := range s2.List() {
+		if !s.Has(item) {
+			result.Insert(item)
+		}
+	}
+	return result
+}
+
+// IsSuperset returns true if and only if s1 is a superset of s2.
+func (s *Ordered[K]) IsSuperset(s2 Set[K]) bool {
+	for _, item := range s2.List() {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if and only if s1 is a subset of s2.
+func (s *Ordered[K]) IsSubset(s2 Set[K]) bool {
+	for e := s.head.next; e != &s.head; e = e.next {
+		if !s2.Has(e.key) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if s and s2 have the same items, regardless of their
+// order.
+func (s *Ordered[K]) Equal(s2 Set[K]) bool {
+	return s.Len() == s2.Len() && s.IsSubset(s2)
+}
+
+func (s *Ordered[K]) Clone() *Ordered[K] {
+	return NewOrdered(s.List()...)
+}
+
+// List returns the items of s in insertion order.
+func (s *Ordered[K]) List() []K {
+	res := make([]K, 0, len(s.entries))
+	for e := s.head.next; e != &s.head; e = e.next {
+		res = append(res, e.key)
+	}
+	return res
+}
+
+// PopFirst removes and returns the oldest item of s.
+func (s *Ordered[K]) PopFirst() (K, bool) {
+	if s.Len() == 0 {
+		var zeroValue K
+		return zeroValue, false
+	}
+
+	key := s.head.next.key
+	s.Delete(key)
+	return key, true
+}
+
+func (s *Ordered[K]) Len() int {
+	return len(s.entries)
+}
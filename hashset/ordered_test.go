@@ -0,0 +1,53 @@
+package hashset
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestOrdered(t *testing.T) {
+	s := NewOrdered(3, 1, 2, 1)
+	assert.DeepEqual(t, s.List(), []int{3, 1, 2})
+	assert.Equal(t, s.Len(), 3)
+
+	s.Insert(0, 3).Delete(1, 7)
+	assert.DeepEqual(t, s.List(), []int{3, 2, 0})
+	assert.Assert(t, s.Has(3))
+	assert.Assert(t, !s.Has(1))
+	assert.Assert(t, s.HasAll(2, 3))
+	assert.Assert(t, s.HasAny(1, 0))
+
+	s.Insert(1)
+	assert.DeepEqual(t, s.List(), []int{3, 2, 0, 1})
+
+	first, ok := s.PopFirst()
+	assert.Assert(t, ok)
+	assert.Equal(t, first, 3)
+	assert.DeepEqual(t, s.List(), []int{2, 0, 1})
+
+	empty := NewOrdered[int]()
+	_, ok = empty.PopFirst()
+	assert.Assert(t, !ok)
+	assert.DeepEqual(t, empty.List(), []int{})
+}
+
+func TestOrderedSetOperations(t *testing.T) {
+	s := NewOrdered(4, 2, 3, 1)
+	s2 := NewOrdered(5, 3, 4)
+
+	assert.DeepEqual(t, s.Union(s2).List(), []int{4, 2, 3, 1, 5})
+	assert.DeepEqual(t, s.Intersection(s2).List(), []int{4, 3})
+	assert.DeepEqual(t, s.Difference(s2).List(), []int{2, 1})
+	assert.DeepEqual(t, s.SymmetricDifference(s2).List(), []int{2, 1, 5})
+	assert.Assert(t, s.Union(s2).IsSuperset(s2))
+	assert.Assert(t, s.Intersection(s2).IsSubset(s2))
+	assert.Assert(t, !s.IsSubset(s2))
+	assert.Assert(t, s.Equal(NewAny(1, 2, 3, 4)))
+	assert.Assert(t, !s.Equal(s2))
+
+	c := s.Clone()
+	c.Delete(4)
+	assert.DeepEqual(t, s.List(), []int{4, 2, 3, 1})
+	assert.DeepEqual(t, c.List(), []int{2, 3, 1})
+}
@@ -0,0 +1,229 @@
+package hashset
+
+import "sort"
+
+// Sorted is a set keeping its items in ascending order according to a
+// comparator, items being equal when the comparator returns 0. It supports
+// range queries such as Floor, Ceiling and Between.
+//
+// Items are stored in a sorted slice: lookups take O(log n) time, while
+// insertions and deletions take O(n) time. A Sorted must be created with
+// NewSorted.
+type Sorted[K any] struct {
+	compare func(K, K) int
+	items   []K
+}
+
+func NewSorted[K any](compare func(K, K) int, items ...K) *Sorted[K] {
+	sorted := make([]K, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compare(sorted[i], sorted[j]) < 0
+	})
+
+	s := &Sorted[K]{compare: compare, items: sorted[:0]}
+	for _, item := range sorted {
+		if len(s.items) == 0 || compare(s.items[len(s.items)-1], item) != 0 {
+			s.items = append(s.items, item)
+		}
+	}
+	return s
+}
+
+// search returns the index of the first item not less than item, and whether
+// it is equal to item.
+func (s *Sorted[K]) search(item K) (int, bool) {
+	i := sort.Search(len(s.items), func(i int) bool {
+		return s.compare(s.items[i], item) >= 0
+	})
+	return i, i < len(s.items) && s.compare(s.items[i], item) == 0
+}
+
+func (s *Sorted[K]) Insert(items ...K) *Sorted[K] {
+	for _, item := range items {
+		i, found := s.search(item)
+		if found {
+			continue
+		}
+
+		var zeroValue K
+		s.items = append(s.items, zeroValue)
+		copy(s.items[i+1:], s.items[i:])
+		s.items[i] = item
+	}
+	return s
+}
+
+func (s *Sorted[K]) Delete(items ...K) *Sorted[K] {
+	for _, item := range items {
+		i, found := s.search(item)
+		if !found {
+			continue
+		}
+
+		copy(s.items[i:], s.items[i+1:])
+		var zeroValue K
+		s.items[len(s.items)-1] = zeroValue
+		s.items = s.items[:len(s.items)-1]
+	}
+	return s
+}
+
+func (s *Sorted[K]) Has(item K) bool {
+	_, found := s.search(item)
+	return found
+}
+
+func (s *Sorted[K]) HasAll(items ...K) bool {
+	for _, item := range items {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Sorted[K]) HasAny(items ...K) bool {
+	for _, item := range items {
+		if s.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest item of s.
+func (s *Sorted[K]) Min() (K, bool) {
+	if len(s.items) == 0 {
+		var zeroValue K
+		return zeroValue, false
+	}
+	return s.items[0], true
+}
+
+// Max returns the greatest item of s.
+func (s *Sorted[K]) Max() (K, bool) {
+	if len(s.items) == 0 {
+		var zeroValue K
+		return zeroValue, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Floor returns the greatest item of s less than or equal to item.
+func (s *Sorted[K]) Floor(item K) (K, bool) {
+	i, found := s.search(item)
+	if found {
+		return s.items[i], true
+	}
+	if i == 0 {
+		var zeroValue K
+		return zeroValue, false
+	}
+	return s.items[i-1], true
+}
+
+// Ceiling returns the smallest item of s greater than or equal to item.
+func (s *Sorted[K]) Ceiling(item K) (K, bool) {
+	i, _ := s.search(item)
+	if i == len(s.items) {
+		var zeroValue K
+		return zeroValue, false
+	}
+	return s.items[i], true
+}
+
+// Between returns the items of s greater than or equal to from and less than
+// or equal to to, in ascending order.
+func (s *Sorted[K]) Between(from, to K) []K {
+	lo, _ := s.search(from)
+	hi := sort.Search(len(s.items), func(i int) bool {
+		return s.compare(s.items[i], to) > 0
+	})
+	if lo >= hi {
+		return []K{}
+	}
+
+	res := make([]K, hi-lo)
+	copy(res, s.items[lo:hi])
+	return res
+}
+
+// Difference returns the items of s that are not in s2.
+func (s *Sorted[K]) Difference(s2 Set[K]) *Sorted[K] {
+	result := &Sorted[K]{compare: s.compare}
+	for _, item := range s.items {
+		if !s2.Has(item) {
+			result.items = append(result.items, item)
+		}
+	}
+	return result
+}
+
+// Union returns the items that are in s or s2.
+func (s *Sorted[K]) Union(s2 Set[K]) *Sorted[K] {
+	result := s.Clone()
+	result.Insert(s2.List()...)
+	return result
+}
+
+// Intersection returns the items of s that are also in s2.
+func (s *Sorted[K]) Intersection(s2 Set[K]) *Sorted[K] {
+	result := &Sorted[K]{compare: s.compare}
+	for _, item := range s.items {
+		if s2.Has(item) {
+			result.items = append(result.items, item)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns the items that are in exactly one of s and s2.
+func (s *Sorted[K]) SymmetricDifference(s2 Set[K]) *Sorted[K] {
+	result := s.Difference(s2)
+	for _, item := range s2.List() {
+		if !s.Has(item) {
+			result.Insert(item)
+		}
+	}
+	return result
+}
+
+// IsSuperset returns true if and only if s1 is a superset of s2.
+func (s *Sorted[K]) IsSuperset(s2 Set[K]) bool {
+	for _, item := range s2.List() {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if and only if s1 is a subset of s2.
+func (s *Sorted[K]) IsSubset(s2 Set[K]) bool {
+	for _, item := range s.items {
+		if !s2.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Sorted[K]) Equal(s2 Set[K]) bool {
+	return s.Len() == s2.Len() && s.IsSubset(s2)
+}
+
+func (s *Sorted[K]) Clone() *Sorted[K] {
+	return &Sorted[K]{compare: s.compare, items: s.List()}
+}
+
+// List returns the items of s in ascending order.
+func (s *Sorted[K]) List() []K {
+	res := make([]K, len(s.items))
+	copy(res, s.items)
+	return res
+}
+
+func (s *Sorted[K]) Len() int {
+	return len(s.items)
+}
@@ -0,0 +1,93 @@
+package hashset
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func compareInts(i, j int) int {
+	return i - j
+}
+
+func TestSorted(t *testing.T) {
+	s := NewSorted(compareInts, 5, 1, 9, 1, 3)
+	assert.DeepEqual(t, s.List(), []int{1, 3, 5, 9})
+
+	s.Insert(4, 10, 0, 5).Delete(9, 2)
+	assert.DeepEqual(t, s.List(), []int{0, 1, 3, 4, 5, 10})
+	assert.Equal(t, s.Len(), 6)
+	assert.Assert(t, s.Has(4))
+	assert.Assert(t, !s.Has(9))
+	assert.Assert(t, s.HasAll(0, 10))
+	assert.Assert(t, !s.HasAny(2, 9))
+
+	min, ok := s.Min()
+	assert.Assert(t, ok)
+	assert.Equal(t, min, 0)
+	max, ok := s.Max()
+	assert.Assert(t, ok)
+	assert.Equal(t, max, 10)
+
+	_, ok = NewSorted(compareInts).Min()
+	assert.Assert(t, !ok)
+	_, ok = NewSorted(compareInts).Max()
+	assert.Assert(t, !ok)
+}
+
+func TestSortedRange(t *testing.T) {
+	s := NewSorted(compareInts, 10, 20, 30, 40)
+
+	tests := []struct {
+		item            int
+		floor, ceiling  int
+		floorOk, ceilOk bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{25, 20, 30, true, true},
+		{40, 40, 40, true, true},
+		{45, 40, 0, true, false},
+	}
+	for _, test := range tests {
+		floor, ok := s.Floor(test.item)
+		assert.Equal(t, ok, test.floorOk)
+		assert.Equal(t, floor, test.floor)
+		ceiling, ok := s.Ceiling(test.item)
+		assert.Equal(t, ok, test.ceilOk)
+		assert.Equal(t, ceiling, test.ceiling)
+	}
+
+	assert.DeepEqual(t, s.Between(15, 40), []int{20, 30, 40})
+	assert.DeepEqual(t, s.Between(10, 10), []int{10})
+	assert.DeepEqual(t, s.Between(31, 39), []int{})
+	assert.DeepEqual(t, s.Between(40, 10), []int{})
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	s := NewSorted(compareInts, 1, 2, 3, 4)
+	s2 := NewAny(3, 4, 5)
+
+	assert.DeepEqual(t, s.Union(s2).List(), []int{1, 2, 3, 4, 5})
+	assert.DeepEqual(t, s.Intersection(s2).List(), []int{3, 4})
+	assert.DeepEqual(t, s.Difference(s2).List(), []int{1, 2})
+	assert.DeepEqual(t, s.SymmetricDifference(s2).List(), []int{1, 2, 5})
+	assert.Assert(t, s.Intersection(s2).IsSubset(s2))
+	assert.Assert(t, s.Union(s2).IsSuperset(s2))
+	assert.Assert(t, s.Equal(NewOrdered(4, 3, 2, 1)))
+
+	c := s.Clone()
+	c.Insert(0)
+	assert.DeepEqual(t, s.List(), []int{1, 2, 3, 4})
+	assert.DeepEqual(t, c.List(), []int{0, 1, 2, 3, 4})
+}
+
+func TestSortedComparator(t *testing.T) {
+	s := NewSorted(func(s1, s2 string) int {
+		return strings.Compare(strings.ToLower(s1), strings.ToLower(s2))
+	}, "b", "A", "a", "C")
+
+	assert.DeepEqual(t, s.List(), []string{"A", "b", "C"})
+	assert.Assert(t, s.Has("B"))
+}
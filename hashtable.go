@@ -0,0 +1,66 @@
+package flinx
+
+import "github.com/kom0055/go-flinx/generics"
+
+// hashTable is a set of values keyed on the hash computed by an
+// EqualityComparer, values with the same hash being told apart by its Equals
+// function.
+type hashTable[T any] struct {
+	comparer generics.EqualityComparer[T]
+	buckets  map[uint64][]T
+}
+
+func newHashTable[T any](comparer generics.EqualityComparer[T]) *hashTable[T] {
+	return &hashTable[T]{
+		comparer: comparer,
+		buckets:  map[uint64][]T{},
+	}
+}
+
+func (h *hashTable[T]) find(item T) (uint64, int) {
+	hash := h.comparer.Hash(item)
+	for i, candidate := range h.buckets[hash] {
+		if h.comparer.Equals(candidate, item) {
+			return hash, i
+		}
+	}
+	return hash, -1
+}
+
+// Insert adds item to the table, it returns false if an equal item was already
+// there.
+func (h *hashTable[T]) Insert(item T) bool {
+	hash, index := h.find(item)
+	if index >= 0 {
+		return false
+	}
+
+	h.buckets[hash] = append(h.buckets[hash], item)
+	return true
+}
+
+// Delete removes the item equal to item from the table, it returns false if
+// there was none.
+func (h *hashTable[T]) Delete(item T) bool {
+	hash, index := h.find(item)
+	if index < 0 {
+		return false
+	}
+
+	bucket := h.buckets[hash]
+	if len(bucket) == 1 {
+		delete(h.buckets, hash)
+		return true
+	}
+	last := len(bucket) - 1
+	bucket[index] = bucket[last]
+	var zero T
+	bucket[last] = zero
+	h.buckets[hash] = bucket[:last]
+	return true
+}
+
+func (h *hashTable[T]) Has(item T) bool {
+	_, index := h.find(item)
+	return index >= 0
+}
@@ -1,6 +1,9 @@
 package flinx
 
-import "github.com/kom0055/go-flinx/hashset"
+import (
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashset"
+)
 
 // Intersect produces the set intersection of the source collection and the
 // provided input collection. The intersection of two sets A and B is defined as
@@ -69,3 +72,33 @@ func IntersectBy[T any, V comparable](selector func(T) V) func(q, q2 Query[T]) Q
 	}
 
 }
+
+// IntersectUsing produces the set intersection of the source collection and
+// the provided input collection, using comparer to find equal elements, so it
+// works for elements that are not comparable with ==.
+func IntersectUsing[T any](comparer generics.EqualityComparer[T]) func(q, q2 Query[T]) Query[T] {
+	return func(q, q2 Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next := q.Iterate()
+				next2 := q2.Iterate()
+
+				set := newHashTable(comparer)
+				for item, ok := next2(); ok; item, ok = next2() {
+					set.Insert(item)
+				}
+
+				return func() (item T, ok bool) {
+					for item, ok = next(); ok; item, ok = next() {
+						if set.Delete(item) {
+							return
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
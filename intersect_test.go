@@ -1,6 +1,10 @@
 package flinx
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/kom0055/go-flinx/generics"
+)
 
 func TestIntersect(t *testing.T) {
 	input1 := []int{1, 2, 3}
@@ -23,3 +27,13 @@ func TestIntersectBy(t *testing.T) {
 		t.Errorf("From(%v).IntersectBy(%v)=%v expected %v", input1, input2, ToSlice(q), want)
 	}
 }
+
+func TestIntersectUsing(t *testing.T) {
+	input1 := []string{"Go", "Rust", "go", "Zig"}
+	input2 := []string{"GO", "zig", "C"}
+	want := []string{"Go", "Zig"}
+
+	if q := IntersectUsing(generics.CaseInsensitiveEqualityComparer())(FromSlice(input1), FromSlice(input2)); !ValidateQuery(q, want) {
+		t.Errorf("From(%v).IntersectUsing(%v)=%v expected %v", input1, input2, ToSlice(q), want)
+	}
+}
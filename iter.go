@@ -0,0 +1,133 @@
+//go:build go1.23
+
+package flinx
+
+import "iter"
+
+// FromSeq initializes a linq query with passed iter.Seq, linq pulls items from
+// the sequence one by one through iter.Pull.
+//
+// The pulled sequence is stopped as soon as it is exhausted, or when a for
+// range loop over All breaks, whatever operators are chained after FromSeq.
+// Otherwise, if the consumer abandons the iterator before reaching the end,
+// the sequence is stopped once the iterator is garbage collected.
+func FromSeq[T any](seq iter.Seq[T]) Query[T] {
+	return Query[T]{
+		Iterate: func() Iterator[T] {
+			next, stop := iter.Pull(seq)
+			r := newReleaser(stop)
+
+			return func() (item T, ok bool) {
+				item, ok = next()
+				if !ok {
+					r.release()
+				}
+
+				return
+			}
+		},
+	}
+}
+
+// FromSeq2 initializes a linq query with passed iter.Seq2, each pair yielded by
+// the sequence is emitted as a KeyValue. It behaves like FromSeq regarding
+// stopping the sequence.
+func FromSeq2[K comparable, V any](seq iter.Seq2[K, V]) Query[KeyValue[K, V]] {
+	return Query[KeyValue[K, V]]{
+		Iterate: func() Iterator[KeyValue[K, V]] {
+			next, stop := iter.Pull2(seq)
+			r := newReleaser(stop)
+
+			return func() (item KeyValue[K, V], ok bool) {
+				var (
+					k K
+					v V
+				)
+				k, v, ok = next()
+				if !ok {
+					r.release()
+					return
+				}
+
+				return KeyValue[K, V]{Key: k, Value: v}, true
+			}
+		},
+	}
+}
+
+// All returns an iter.Seq over the elements of the query, so it can be used in
+// a for range loop or passed to the slices and maps packages.
+//
+// Breaking out of the loop stops pulling from the query immediately, no more
+// upstream elements are evaluated. The resources held by the query, such as
+// the sequences pulled by FromSeq or the goroutines of parallel queries, are
+// released as soon as the loop ends, without waiting for the garbage
+// collector.
+func (q Query[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		releaseScoped(func(s *releaseScope) {
+			next := q.Iterate()
+
+			for item, ok := next(); ok; item, ok = next() {
+				s.paused = true
+				more := yield(item)
+				s.paused = false
+				if !more {
+					return
+				}
+			}
+		})
+	}
+}
+
+// All returns an iter.Seq2 over the elements of a fallible query and a nil
+// error. If evaluating the query fails, the error is yielded with a zero
+// element as the last pair. Like Query.All, breaking out of the loop releases
+// the resources held by the query at once.
+func (q TryQuery[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		releaseScoped(func(s *releaseScope) {
+			next := q.Iterate()
+
+			item, ok, err := next()
+			for ; ok; item, ok, err = next() {
+				s.paused = true
+				more := yield(item, nil)
+				s.paused = false
+				if !more {
+					return
+				}
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+			}
+		})
+	}
+}
+
+// Indexed returns an iter.Seq2 over the zero-based index and the element of the
+// query, like slices.All does for a slice.
+func (q Query[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for item := range q.All() {
+			if !yield(index, item) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// ToSeq2 returns an iter.Seq2 over the keys and values of a query of KeyValue
+// elements, so the result can be collected with maps.Collect.
+func ToSeq2[K comparable, V any](q Query[KeyValue[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for item := range q.All() {
+			if !yield(item.Key, item.Value) {
+				return
+			}
+		}
+	}
+}
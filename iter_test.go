@@ -0,0 +1,262 @@
+//go:build go1.23
+
+package flinx
+
+import (
+	"errors"
+	"maps"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFromSeq(t *testing.T) {
+	q := FromSeq(slices.Values([]int{1, 2, 3}))
+	if !ValidateQuery(q, []int{1, 2, 3}) {
+		t.Errorf("FromSeq()=%v expected %v", ToSlice(q), []int{1, 2, 3})
+	}
+
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; i < 3; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	assert.DeepEqual(t, ToSlice(FromSeq(seq)), []int{0, 1, 2})
+	assert.Assert(t, stopped)
+}
+
+func TestFromSeqBreak(t *testing.T) {
+	stopped := 0
+	seq := func(yield func(int) bool) {
+		defer func() { stopped++ }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	seq2 := func(yield func(int, string) bool) {
+		defer func() { stopped++ }()
+		for i := 0; ; i++ {
+			if !yield(i, "x") {
+				return
+			}
+		}
+	}
+
+	for v := range FromSeq(seq).All() {
+		if v == 2 {
+			break
+		}
+	}
+	assert.Equal(t, stopped, 1)
+
+	for i := range FromSeq(seq).Indexed() {
+		if i == 2 {
+			break
+		}
+	}
+	assert.Equal(t, stopped, 2)
+
+	for k := range ToSeq2(FromSeq2(seq2)) {
+		if k == 2 {
+			break
+		}
+	}
+	assert.Equal(t, stopped, 3)
+
+	for kv := range FromSeq2(seq2).All() {
+		if kv.Key == 2 {
+			break
+		}
+	}
+	assert.Equal(t, stopped, 4)
+}
+
+func TestQueryAllBreakStops(t *testing.T) {
+	stopped := 0
+	seq := func(yield func(int) bool) {
+		defer func() { stopped++ }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	q := Select(func(i int) int { return i * 10 })(Where(func(i int) bool { return i%2 == 0 })(FromSeq(seq)))
+	var got []int
+	for v := range q.All() {
+		if v >= 40 {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.DeepEqual(t, got, []int{0, 20})
+	assert.Equal(t, stopped, 1)
+
+	// The inner sequences are pulled lazily, while the loop is running.
+	inner := SelectMany(func(i int) Query[int] {
+		return Take(FromSeq(seq), 2)
+	})(Range(0, 3))
+	for range inner.Indexed() {
+		break
+	}
+	assert.Equal(t, stopped, 2)
+
+	// The source of a parallel query is stopped by its feeder goroutine.
+	parallelStopped := make(chan struct{})
+	parallelSeq := func(yield func(int) bool) {
+		defer close(parallelStopped)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	pq := ParallelSelect(Self[int])(AsParallel[int](4)(FromSeq(parallelSeq)))
+	for range pq.Query.All() {
+		break
+	}
+	select {
+	case <-parallelStopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("parallel FromSeq source was never stopped")
+	}
+}
+
+func TestQueryAllBodyUnscoped(t *testing.T) {
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	// An iterator created by the loop body outlives the loop.
+	var next Iterator[int]
+	for range Range(0, 3).All() {
+		next = FromSeq(seq).Iterate()
+		break
+	}
+	assert.Assert(t, !stopped)
+	first, _ := next()
+	second, _ := next()
+	assert.Equal(t, first+second, 1)
+}
+
+// TestFromSeqAbandoned checks the fallback for iterators that are pulled
+// manually and abandoned.
+func TestFromSeqAbandoned(t *testing.T) {
+	stopped := make(chan struct{})
+	seq := func(yield func(int) bool) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	func() {
+		first, ok := First(FromSeq(seq))
+		assert.Assert(t, ok)
+		assert.Equal(t, first, 0)
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-stopped:
+			return
+		case <-deadline:
+			t.Fatal("abandoned FromSeq iterator was never stopped")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestFromSeq2(t *testing.T) {
+	q := FromSeq2(slices.All([]string{"a", "b"}))
+	assert.DeepEqual(t, ToSlice(q), []KeyValue[int, string]{{0, "a"}, {1, "b"}})
+}
+
+func TestQueryAll(t *testing.T) {
+	assert.DeepEqual(t, slices.Collect(Range(1, 5).All()), []int{1, 2, 3, 4, 5})
+
+	pulled := 0
+	q := Select(func(i int) int {
+		pulled++
+		return i * 2
+	})(Range(0, 100))
+
+	var got []int
+	for v := range q.All() {
+		if v >= 6 {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.DeepEqual(t, got, []int{0, 2, 4})
+	assert.Equal(t, pulled, 4)
+}
+
+func TestQueryIndexed(t *testing.T) {
+	var got []KeyValue[int, rune]
+	for i, r := range FromString("abc").Indexed() {
+		got = append(got, KeyValue[int, rune]{i, r})
+	}
+	assert.DeepEqual(t, got, []KeyValue[int, rune]{{0, 'a'}, {1, 'b'}, {2, 'c'}})
+}
+
+func TestToSeq2(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	assert.DeepEqual(t, maps.Collect(ToSeq2(FromMap(m))), m)
+}
+
+func TestTryQueryAll(t *testing.T) {
+	var got []int
+	for v, err := range AsTry(Range(1, 3)).All() {
+		assert.NilError(t, err)
+		got = append(got, v)
+	}
+	assert.DeepEqual(t, got, []int{1, 2, 3})
+
+	failing := TrySelect(func(i int) (int, error) {
+		if i == 2 {
+			return 0, errors.New("select")
+		}
+		return i, nil
+	})(AsTry(Range(1, 3)))
+	got = nil
+	var errs []error
+	for v, err := range failing.All() {
+		got = append(got, v)
+		errs = append(errs, err)
+	}
+	assert.DeepEqual(t, got, []int{1, 0})
+	assert.Assert(t, errs[0] == nil)
+	assert.Error(t, errs[1], "element 1: select")
+
+	dir := t.TempDir()
+	q := Select(func(i int) foo {
+		return foo{f1: i}
+	})(Range(0, 100))
+	for f, err := range ExternalSort[foo](compareF1, fooCodec{-1, -1}, 10, dir)(Reverse(q)).All() {
+		assert.NilError(t, err)
+		assert.Equal(t, f.f1, 0)
+		break
+	}
+	assertNoTempFiles(t, dir)
+}
@@ -18,13 +18,7 @@ func Join[T, V, Q any, K comparable](
 		return Query[Q]{
 			Iterate: func() Iterator[Q] {
 				outernext := q.Iterate()
-				innernext := inner.Iterate()
-
-				innerLookup := make(map[K][]V)
-				for innerItem, ok := innernext(); ok; innerItem, ok = innernext() {
-					innerKey := innerKeySelector(innerItem)
-					innerLookup[innerKey] = append(innerLookup[innerKey], innerItem)
-				}
+				innerLookup := ToLookup(innerKeySelector, Self[V])(inner)
 
 				var outerItem T
 				var innerGroup []V
@@ -39,7 +33,7 @@ func Join[T, V, Q any, K comparable](
 								return
 							}
 
-							innerGroup, has = innerLookup[outerKeySelector(outerItem)]
+							innerGroup, has = innerLookup.groups[outerKeySelector(outerItem)]
 							innerLen = len(innerGroup)
 							innerIndex = 0
 						}
@@ -54,3 +48,124 @@ func Join[T, V, Q any, K comparable](
 	}
 
 }
+
+// LeftJoin correlates the elements of two collection based on matching keys,
+// keeping the elements of outer collection that have no matching element in
+// inner collection.
+//
+// resultSelector is called like in Join for each pair of matching elements, and
+// once with a nil inner for each element of outer collection without a match.
+//
+// LeftJoin preserves the order of the elements of outer collection, and for
+// each of these elements, the order of the matching elements of inner.
+func LeftJoin[T, V, Q any, K comparable](
+	outerKeySelector func(T) K,
+	innerKeySelector func(V) K,
+	resultSelector func(outer T, inner *V) Q) func(q Query[T], inner Query[V]) Query[Q] {
+	return func(q Query[T], inner Query[V]) Query[Q] {
+		return Query[Q]{
+			Iterate: func() Iterator[Q] {
+				outernext := q.Iterate()
+				innerLookup := ToLookup(innerKeySelector, Self[V])(inner)
+
+				var outerItem T
+				var innerGroup []V
+				innerIndex := 0
+
+				return func() (item Q, ok bool) {
+					if innerIndex >= len(innerGroup) {
+						if outerItem, ok = outernext(); !ok {
+							return
+						}
+
+						innerGroup = innerLookup.groups[outerKeySelector(outerItem)]
+						innerIndex = 0
+						if len(innerGroup) == 0 {
+							return resultSelector(outerItem, nil), true
+						}
+					}
+
+					innerItem := innerGroup[innerIndex]
+					innerIndex++
+					return resultSelector(outerItem, &innerItem), true
+				}
+			},
+		}
+	}
+
+}
+
+// RightJoin correlates the elements of two collection based on matching keys,
+// keeping the elements of inner collection that have no matching element in
+// outer collection.
+//
+// resultSelector is called like in Join for each pair of matching elements, and
+// once with a nil outer for each element of inner collection without a match.
+//
+// RightJoin preserves the order of the elements of inner collection, and for
+// each of these elements, the order of the matching elements of outer.
+func RightJoin[T, V, Q any, K comparable](
+	outerKeySelector func(T) K,
+	innerKeySelector func(V) K,
+	resultSelector func(outer *T, inner V) Q) func(q Query[T], inner Query[V]) Query[Q] {
+	leftJoin := LeftJoin(innerKeySelector, outerKeySelector, func(inner V, outer *T) Q {
+		return resultSelector(outer, inner)
+	})
+	return func(q Query[T], inner Query[V]) Query[Q] {
+		return leftJoin(inner, q)
+	}
+
+}
+
+// FullOuterJoin correlates the elements of two collection based on matching
+// keys, keeping the elements of both collections that have no match in the
+// other one.
+//
+// resultSelector is called like in Join for each pair of matching elements,
+// once with a nil inner for each element of outer collection without a match,
+// and once with a nil outer for each element of inner collection without a
+// match.
+//
+// FullOuterJoin first emits the results of LeftJoin, preserving the order of
+// the elements of outer collection, then the unmatched elements of inner
+// collection in their order.
+func FullOuterJoin[T, V, Q any, K comparable](
+	outerKeySelector func(T) K,
+	innerKeySelector func(V) K,
+	resultSelector func(outer *T, inner *V) Q) func(q Query[T], inner Query[V]) Query[Q] {
+	return func(q Query[T], inner Query[V]) Query[Q] {
+		return Query[Q]{
+			Iterate: func() Iterator[Q] {
+				innerItems := ToSlice(inner)
+				matched := map[K]struct{}{}
+
+				leftnext := LeftJoin(func(outer T) K {
+					key := outerKeySelector(outer)
+					matched[key] = struct{}{}
+					return key
+				}, innerKeySelector, func(outer T, inner *V) Q {
+					return resultSelector(&outer, inner)
+				})(q, FromSlice(innerItems)).Iterate()
+
+				innerIndex := 0
+
+				return func() (item Q, ok bool) {
+					if item, ok = leftnext(); ok {
+						return
+					}
+
+					for innerIndex < len(innerItems) {
+						innerItem := innerItems[innerIndex]
+						innerIndex++
+						if _, has := matched[innerKeySelector(innerItem)]; !has {
+							return resultSelector(nil, &innerItem), true
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
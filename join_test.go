@@ -1,6 +1,9 @@
 package flinx
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+)
 
 func TestJoin(t *testing.T) {
 	outer := []int{0, 1, 2, 3, 4, 5, 8}
@@ -23,3 +26,76 @@ func TestJoin(t *testing.T) {
 		t.Errorf("From().Join()=%v expected %v", ToSlice(q), want)
 	}
 }
+
+type joinRow struct {
+	outer, inner string
+}
+
+func joinValue(p *int) string {
+	if p == nil {
+		return "-"
+	}
+	return strconv.Itoa(*p)
+}
+
+func TestLeftJoin(t *testing.T) {
+	outer := []int{0, 1, 2, 3}
+	inner := []int{1, 2, 1, 7}
+	want := []joinRow{
+		{"0", "-"},
+		{"1", "1"},
+		{"1", "1"},
+		{"2", "2"},
+		{"3", "-"},
+	}
+
+	q := LeftJoin(Self[int], Self[int], func(outer int, inner *int) joinRow {
+		return joinRow{strconv.Itoa(outer), joinValue(inner)}
+	})(FromSlice(outer), FromSlice(inner))
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().LeftJoin()=%v expected %v", ToSlice(q), want)
+	}
+}
+
+func TestRightJoin(t *testing.T) {
+	outer := []int{0, 1, 2, 1}
+	inner := []int{7, 1, 2, 3}
+	want := []joinRow{
+		{"-", "7"},
+		{"1", "1"},
+		{"1", "1"},
+		{"2", "2"},
+		{"-", "3"},
+	}
+
+	q := RightJoin(Self[int], Self[int], func(outer *int, inner int) joinRow {
+		return joinRow{joinValue(outer), strconv.Itoa(inner)}
+	})(FromSlice(outer), FromSlice(inner))
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().RightJoin()=%v expected %v", ToSlice(q), want)
+	}
+}
+
+func TestFullOuterJoin(t *testing.T) {
+	outer := []int{0, 1, 2, 3}
+	inner := []int{5, 1, 2, 1, 4}
+	want := []joinRow{
+		{"0", "-"},
+		{"1", "1"},
+		{"1", "1"},
+		{"2", "2"},
+		{"3", "-"},
+		{"-", "5"},
+		{"-", "4"},
+	}
+
+	q := FullOuterJoin(Self[int], Self[int], func(outer *int, inner *int) joinRow {
+		return joinRow{joinValue(outer), joinValue(inner)}
+	})(FromSlice(outer), FromSlice(inner))
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().FullOuterJoin()=%v expected %v", ToSlice(q), want)
+	}
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().FullOuterJoin()=%v expected %v on second iteration", ToSlice(q), want)
+	}
+}
@@ -0,0 +1,96 @@
+package flinx
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// FromJSONLines initializes a fallible query with passed reader of JSON Lines
+// data, also known as NDJSON, linq iterates over its lines decoded into values
+// of type T with encoding/json. Blank lines are skipped.
+//
+// Each line is decoded separately, so a malformed line ends the iteration
+// with a LineError holding its line number. Read errors are reported as is.
+// The reader is read lazily, and only once: iterating the query again
+// continues where the previous iteration stopped.
+func FromJSONLines[T any](r io.Reader) TryQuery[T] {
+	lines := FromLines(r)
+	// The reader is shared by all the iterations, so are the line number and
+	// the first error.
+	line := 0
+	var failed error
+
+	return TryQuery[T]{
+		Iterate: func() TryIterator[T] {
+			next := lines.Iterate()
+
+			return func() (item T, ok bool, err error) {
+				if failed != nil {
+					return item, false, failed
+				}
+
+				for {
+					s, ok, err := next()
+					if !ok {
+						failed = err
+						return item, false, err
+					}
+
+					line++
+					if strings.TrimSpace(s) == "" {
+						continue
+					}
+
+					if err := json.Unmarshal([]byte(s), &item); err != nil {
+						var zero T
+						failed = &LineError{Line: line, Err: err}
+						return zero, false, failed
+					}
+
+					return item, true, nil
+				}
+			}
+		},
+	}
+}
+
+// WriteJSONLines writes the elements of a collection to w as JSON Lines data,
+// each element being encoded on its own line with encoding/json. If an element
+// cannot be encoded, the error is wrapped in an ElementError holding its index.
+func WriteJSONLines[T any](w io.Writer) func(q Query[T]) error {
+	write := TryWriteJSONLines[T](w)
+	return func(q Query[T]) error {
+		return write(AsTry(q))
+	}
+}
+
+// TryWriteJSONLines writes the elements of a fallible collection to w like
+// WriteJSONLines does. It stops at the first error of the collection, and
+// returns it.
+func TryWriteJSONLines[T any](w io.Writer) func(q TryQuery[T]) error {
+	return func(q TryQuery[T]) (err error) {
+		releaseScoped(func(*releaseScope) {
+			next := q.iterateIndexed()
+
+			var (
+				item  T
+				index int
+				ok    bool
+			)
+			for item, index, ok, err = next(); ok; item, index, ok, err = next() {
+				b, merr := json.Marshal(item)
+				if merr != nil {
+					err = &ElementError{Index: index, Err: merr}
+					return
+				}
+				if _, err = w.Write(append(b, '\n')); err != nil {
+					return
+				}
+			}
+		})
+
+		return
+	}
+
+}
@@ -0,0 +1,111 @@
+package flinx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"gotest.tools/v3/assert"
+)
+
+type jsonRecord struct {
+	Level string `json:"level"`
+	Code  int    `json:"code"`
+}
+
+func TestFromJSONLines(t *testing.T) {
+	input := "{\"level\":\"info\",\"code\":1}\n\n  \n{\"level\":\"warn\",\"code\":2,\"extra\":true}\r\n{\"level\":\"error\"}"
+	want := []jsonRecord{{"info", 1}, {"warn", 2}, {"error", 0}}
+
+	r, err := TryToSlice(FromJSONLines[jsonRecord](strings.NewReader(input)))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, want)
+
+	values, err := TryToSlice(FromJSONLines[any](strings.NewReader("1\n\"a\"\nnull\n")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []any{1.0, "a", nil})
+}
+
+func TestFromJSONLinesError(t *testing.T) {
+	var lineErr *LineError
+
+	q := FromJSONLines[jsonRecord](strings.NewReader("{\"code\":1}\n\n{\"code\":2}\n{\"code\":\"x\"}\n{\"code\":4}\n"))
+	next := q.Iterate()
+	for _, want := range []int{1, 2} {
+		item, ok, err := next()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.Equal(t, item.Code, want)
+	}
+	for i := 0; i < 2; i++ {
+		_, ok, err := next()
+		assert.Assert(t, !ok)
+		assert.Assert(t, errors.As(err, &lineErr))
+		assert.Equal(t, lineErr.Line, 4)
+		var typeErr *json.UnmarshalTypeError
+		assert.Assert(t, errors.As(err, &typeErr))
+	}
+
+	// Several values on a line are reported as a malformed line.
+	_, err := TryToSlice(FromJSONLines[jsonRecord](strings.NewReader("{} {}\n")))
+	assert.Assert(t, errors.As(err, &lineErr))
+	assert.Equal(t, lineErr.Line, 1)
+
+	_, err = TryToSlice(FromJSONLines[jsonRecord](io.MultiReader(strings.NewReader("{}\n"), iotest.ErrReader(errRead))))
+	assert.ErrorIs(t, err, errRead)
+	assert.Assert(t, !errors.As(err, &lineErr))
+}
+
+func TestFromJSONLinesErrorSticks(t *testing.T) {
+	q := FromJSONLines[jsonRecord](strings.NewReader("{\"code\":1}\n{\n{\"code\":3}\n"))
+	for i := 0; i < 2; i++ {
+		r, err := TryToSlice(q)
+		assert.Assert(t, r == nil)
+		var lineErr *LineError
+		assert.Assert(t, errors.As(err, &lineErr))
+		assert.Equal(t, lineErr.Line, 2)
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	input := []jsonRecord{{"info", 1}, {"warn", 2}}
+
+	var b strings.Builder
+	assert.NilError(t, WriteJSONLines[jsonRecord](&b)(FromSlice(input)))
+	assert.Equal(t, b.String(), "{\"level\":\"info\",\"code\":1}\n{\"level\":\"warn\",\"code\":2}\n")
+
+	r, err := TryToSlice(FromJSONLines[jsonRecord](strings.NewReader(b.String())))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, input)
+
+	b.Reset()
+	err = WriteJSONLines[float64](&b)(FromSlice([]float64{1, math.NaN()}))
+	var elementErr *ElementError
+	assert.Assert(t, errors.As(err, &elementErr))
+	assert.Equal(t, elementErr.Index, 1)
+	assert.Equal(t, b.String(), "1\n")
+
+	assert.ErrorIs(t, WriteJSONLines[int](errWriter{})(FromSlice([]int{1})), errRead)
+
+	// The index is the one of the source element, before filtering.
+	positive := func(f float64) (bool, error) {
+		return !(f <= 0), nil
+	}
+	err = TryWriteJSONLines[float64](io.Discard)(TryWhere(positive)(AsTry(FromSlice([]float64{-1, 1, math.NaN()}))))
+	assert.Assert(t, errors.As(err, &elementErr))
+	assert.Equal(t, elementErr.Index, 2)
+}
+
+func TestTryWriteJSONLines(t *testing.T) {
+	var b strings.Builder
+	err := TryWriteJSONLines[jsonRecord](&b)(FromJSONLines[jsonRecord](strings.NewReader("{\"code\":1}\nnope\n")))
+
+	var lineErr *LineError
+	assert.Assert(t, errors.As(err, &lineErr))
+	assert.Equal(t, lineErr.Line, 2)
+	assert.Equal(t, b.String(), "{\"level\":\"\",\"code\":1}\n")
+}
@@ -0,0 +1,73 @@
+package flinx
+
+// Lookup is a collection of keys each mapped to one or more values. It is the
+// type returned from ToLookup, and keeps its keys in the order they first
+// appeared in the source collection.
+type Lookup[K comparable, V any] struct {
+	keys   []K
+	groups map[K][]V
+}
+
+// ToLookup groups the elements of a collection into a Lookup according to a
+// specified key selector function, and projects the elements for each group by
+// using a specified function.
+//
+// Unlike GroupBy, ToLookup evaluates the collection immediately, and the
+// resulting Lookup can be queried any number of times.
+func ToLookup[T any, K comparable, V any](keySelector func(T) K,
+	elementSelector func(T) V) func(q Query[T]) Lookup[K, V] {
+	return func(q Query[T]) Lookup[K, V] {
+		l := Lookup[K, V]{groups: map[K][]V{}}
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			key := keySelector(item)
+			group, has := l.groups[key]
+			if !has {
+				l.keys = append(l.keys, key)
+			}
+			l.groups[key] = append(group, elementSelector(item))
+		}
+
+		return l
+	}
+
+}
+
+// Get returns a query over the values mapped to key, in the order they appeared
+// in the source collection. The query is empty if key is not in the Lookup.
+//
+// The query reads the values stored in the Lookup, which must be treated as
+// read-only.
+func (l Lookup[K, V]) Get(key K) Query[V] {
+	return FromSlice(l.groups[key])
+}
+
+// Contains determines whether a key is in the Lookup.
+func (l Lookup[K, V]) Contains(key K) bool {
+	_, has := l.groups[key]
+	return has
+}
+
+// Count returns the number of keys in the Lookup.
+func (l Lookup[K, V]) Count() int {
+	return len(l.keys)
+}
+
+// Keys returns a query over the keys of the Lookup, in the order they first
+// appeared in the source collection.
+func (l Lookup[K, V]) Keys() Query[K] {
+	return FromSlice(l.keys)
+}
+
+// Groups returns a query over the groups of the Lookup, in the order their key
+// first appeared in the source collection.
+//
+// The Group slices have their capacity capped to their length, so appending to
+// one allocates instead of overwriting the values stored in the Lookup.
+func (l Lookup[K, V]) Groups() Query[Group[K, V]] {
+	return Select(func(key K) Group[K, V] {
+		group := l.groups[key]
+		return Group[K, V]{key, group[:len(group):len(group)]}
+	})(l.Keys())
+}
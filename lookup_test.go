@@ -0,0 +1,53 @@
+package flinx
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestToLookup(t *testing.T) {
+	input := []string{"apple", "cherry", "avocado", "banana", "blueberry", "apricot"}
+
+	l := ToLookup(func(s string) rune {
+		return []rune(s)[0]
+	}, func(s string) int {
+		return len(s)
+	})(FromSlice(input))
+
+	assert.Equal(t, l.Count(), 3)
+	assert.Assert(t, l.Contains('a'))
+	assert.Assert(t, !l.Contains('z'))
+	assert.DeepEqual(t, ToSlice(l.Get('a')), []int{5, 7, 7})
+	assert.DeepEqual(t, ToSlice(l.Get('z')), []int{})
+	assert.DeepEqual(t, ToSlice(l.Keys()), []rune{'a', 'c', 'b'})
+	want := []Group[rune, int]{
+		{'a', []int{5, 7, 7}},
+		{'c', []int{6}},
+		{'b', []int{6, 9}},
+	}
+	assert.DeepEqual(t, ToSlice(l.Groups()), want)
+	assert.DeepEqual(t, ToSlice(l.Groups()), want)
+}
+
+func TestToLookupEmpty(t *testing.T) {
+	l := ToLookup(Self[int], Self[int])(FromSlice([]int{}))
+
+	assert.Equal(t, l.Count(), 0)
+	assert.Assert(t, !l.Contains(0))
+	assert.DeepEqual(t, ToSlice(l.Groups()), []Group[int, int]{})
+}
+
+func TestToLookupGroupsAppend(t *testing.T) {
+	l := ToLookup(func(i int) int { return i % 2 }, Self[int])(Range(0, 6))
+
+	first := ToSlice(l.Groups())[0].Group
+	second := ToSlice(l.Groups())[0].Group
+	first = append(first, -1)
+	second = append(second, -2)
+	assert.DeepEqual(t, first, []int{0, 2, 4, -1})
+	assert.DeepEqual(t, second, []int{0, 2, 4, -2})
+
+	grouped := ToSlice(GroupBy(func(i int) int { return i % 2 }, Self[int])(Range(0, 6)))
+	assert.Equal(t, cap(grouped[1].Group), len(grouped[1].Group))
+}
@@ -0,0 +1,80 @@
+package flinx
+
+// MergeJoin correlates the elements of two collections based on matching keys,
+// like Join does, for collections that are both sorted in ascending order of
+// their key according to compare, such as the result of OrderBy or two sorted
+// files.
+//
+// Both collections are streamed in lockstep, so MergeJoin never materialises a
+// whole collection: only the run of inner elements sharing the current key is
+// buffered. The result is undefined if a collection is not sorted.
+//
+// MergeJoin preserves the order of the elements of outer collection, and for
+// each of these elements, the order of the matching elements of inner.
+func MergeJoin[T, V, Q, K any](
+	compare func(K, K) int,
+	outerKeySelector func(T) K,
+	innerKeySelector func(V) K,
+	resultSelector func(outer T, inner V) Q) func(q Query[T], inner Query[V]) Query[Q] {
+	return func(q Query[T], inner Query[V]) Query[Q] {
+		return Query[Q]{
+			Iterate: func() Iterator[Q] {
+				outernext := q.Iterate()
+				innernext := inner.Iterate()
+
+				var (
+					outerItem T
+					innerItem V
+					innerKey  K
+					runKey    K
+					run       []V
+				)
+				innerOk, started := false, false
+				runIndex := 0
+
+				pullInner := func() {
+					if innerItem, innerOk = innernext(); innerOk {
+						innerKey = innerKeySelector(innerItem)
+					}
+				}
+
+				return func() (item Q, ok bool) {
+					if !started {
+						started = true
+						pullInner()
+					}
+
+					for {
+						if runIndex < len(run) {
+							item = resultSelector(outerItem, run[runIndex])
+							runIndex++
+							return item, true
+						}
+
+						if outerItem, ok = outernext(); !ok {
+							run = nil
+							return
+						}
+						outerKey := outerKeySelector(outerItem)
+						runIndex = 0
+
+						if len(run) > 0 && compare(outerKey, runKey) == 0 {
+							continue
+						}
+
+						run = run[:0]
+						for innerOk && compare(innerKey, outerKey) < 0 {
+							pullInner()
+						}
+						for innerOk && compare(innerKey, outerKey) == 0 {
+							run = append(run, innerItem)
+							pullInner()
+						}
+						runKey = outerKey
+					}
+				}
+			},
+		}
+	}
+
+}
@@ -0,0 +1,53 @@
+package flinx
+
+import (
+	"testing"
+
+	"github.com/kom0055/go-flinx/generics"
+)
+
+func TestMergeJoin(t *testing.T) {
+	outer := []int{0, 1, 1, 2, 3, 4, 5, 8}
+	inner := []int{1, 1, 2, 2, 4, 6, 7, 7, 8}
+	want := ToSlice(Join(Self[int], Self[int], func(outer, inner int) KeyValue[int, int] {
+		return KeyValue[int, int]{outer, inner}
+	})(FromSlice(outer), FromSlice(inner)))
+
+	q := MergeJoin(generics.OrderedCompare[int], Self[int], Self[int],
+		func(outer, inner int) KeyValue[int, int] {
+			return KeyValue[int, int]{outer, inner}
+		},
+	)(FromSlice(outer), FromSlice(inner))
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().MergeJoin()=%v expected %v", ToSlice(q), want)
+	}
+}
+
+func TestMergeJoinOrdered(t *testing.T) {
+	outer := []foo{{f1: 3, f3: "c"}, {f1: 1, f3: "a"}, {f1: 2, f3: "b"}}
+	inner := []foo{{f1: 2, f3: "y"}, {f1: 3, f3: "z"}, {f1: 1, f3: "x"}}
+	want := []string{"ax", "by", "cz"}
+
+	orderBy := OrderBy(generics.OrderedCompare[int], getF1)
+	q := MergeJoin(generics.OrderedCompare[int], getF1, getF1, func(outer, inner foo) string {
+		return outer.f3 + inner.f3
+	})(orderBy(FromSlice(outer)).Query, orderBy(FromSlice(inner)).Query)
+	if !ValidateQuery(q, want) {
+		t.Errorf("From().MergeJoin()=%v expected %v", ToSlice(q), want)
+	}
+}
+
+func TestMergeJoinEmpty(t *testing.T) {
+	selector := func(outer, inner int) int {
+		return outer + inner
+	}
+
+	q := MergeJoin(generics.OrderedCompare[int], Self[int], Self[int], selector)(FromSlice([]int{}), FromSlice([]int{1}))
+	if !ValidateQuery(q, []int{}) {
+		t.Errorf("From().MergeJoin()=%v expected empty", ToSlice(q))
+	}
+	q = MergeJoin(generics.OrderedCompare[int], Self[int], Self[int], selector)(FromSlice([]int{1}), FromSlice([]int{}))
+	if !ValidateQuery(q, []int{}) {
+		t.Errorf("From().MergeJoin()=%v expected empty", ToSlice(q))
+	}
+}
@@ -1,6 +1,7 @@
 package flinx
 
 import (
+	"container/heap"
 	"sort"
 )
 
@@ -19,6 +20,16 @@ type OrderedQuery[T any] struct {
 
 // OrderBy sorts the elements of a collection in ascending order. Elements are
 // sorted according to a key.
+//
+// The sort is stable: elements with equal keys keep their relative order in the
+// collection, and so do elements equal for every criteria of subsequent ThenBy
+// and ThenByDescending methods.
+//
+// The elements are sorted lazily: iterating the query buffers the collection
+// into a heap in O(n) time, then each element is popped from the heap in
+// O(log n) time. Taking the first k elements, with Take or First, thus costs
+// O(n + k log n) instead of sorting the whole collection. TakeOrdered uses
+// O(k) memory instead of O(n).
 func OrderBy[T, V any](compare func(V, V) int, selector func(T) V) func(q Query[T]) OrderedQuery[T] {
 
 	return func(q Query[T]) OrderedQuery[T] {
@@ -64,15 +75,12 @@ func ThenBy[T, V any](compare func(V, V) int, selector func(T) V) func(oq Ordere
 			original: oq.original,
 			Query: Query[T]{
 				Iterate: func() Iterator[T] {
-					items := sortQuery[T](cmp)(oq.original)
-					length := len(items)
-					index := 0
+					h := heapQuery[T](cmp)(oq.original)
 
 					return func() (item T, ok bool) {
-						ok = index < length
+						ok = h.Len() > 0
 						if ok {
-							item = items[index]
-							index++
+							item = heap.Pop(h).(rankedItem[T]).item
 						}
 
 						return
@@ -101,6 +109,8 @@ func ThenByDescending[T, V any](compare func(V, V) int, selector func(T) V) func
 // is less than j. While this method is uglier than chaining OrderBy,
 // OrderByDescending, ThenBy and ThenByDescending methods, it's performance is
 // much better.
+//
+// Like OrderBy, the sort is stable.
 func Sort[T any](less func(i, j T) bool) func(q Query[T]) Query[T] {
 	lessFn := lessSort[T](less)
 	return func(q Query[T]) Query[T] {
@@ -142,26 +152,30 @@ func (s sorter[T]) Less(i, j int) bool {
 	return s.less(s.items[i], s.items[j])
 }
 
-func sortQuery[T any](cmp func(T, T) int) func(q Query[T]) (r []T) {
-	return func(q Query[T]) (r []T) {
-		next := q.Iterate()
-		for item, ok := next(); ok; item, ok = next() {
-			r = append(r, item)
-		}
-
-		if len(r) == 0 {
-			return
+// heapQuery collects the elements of a collection into a heap whose root is
+// the smallest element according to cmp. Equal elements are ranked by their
+// position in the collection, so popping the heap yields a stable sort.
+//
+// Building the heap takes O(n) time and each pop O(log n), so the first k
+// elements are sorted in O(n + k log n) time.
+func heapQuery[T any](cmp func(T, T) int) func(q Query[T]) *rankHeap[T] {
+	return func(q Query[T]) *rankHeap[T] {
+		h := &rankHeap[T]{
+			less: func(r1, r2 rankedItem[T]) bool {
+				if c := cmp(r1.item, r2.item); c != 0 {
+					return c > 0
+				}
+				return r1.index > r2.index
+			},
 		}
 
-		s := sorter[T]{
-			items: r,
-			less: func(i, j T) bool {
-				return cmp(i, j) < 0
-			},
+		next := q.Iterate()
+		for item, ok := next(); ok; item, ok = next() {
+			h.items = append(h.items, rankedItem[T]{item, len(h.items)})
 		}
+		heap.Init(h)
 
-		sort.Sort(s)
-		return
+		return h
 	}
 
 }
@@ -175,7 +189,7 @@ func lessSort[T any](less func(i, j T) bool) func(q Query[T]) (r []T) {
 
 		s := sorter[T]{items: r, less: less}
 
-		sort.Sort(s)
+		sort.Stable(s)
 		return
 	}
 
@@ -119,3 +119,82 @@ func TestSort(t *testing.T) {
 		j++
 	}
 }
+
+func TestOrderByStable(t *testing.T) {
+	slice := make([]foo, 1000)
+
+	for i := range slice {
+		slice[i].f1 = i
+		slice[i].f2 = i%3 == 0
+	}
+
+	q := OrderBy(generics.BoolCompare, getF2)(FromSlice(slice))
+
+	prev := foo{f1: -1}
+	next := q.Iterate()
+	for item, ok := next(); ok; item, ok = next() {
+		if item.f2 == prev.f2 && item.f1 < prev.f1 {
+			t.Fatalf("OrderBy() is not stable, %v emitted after %v", item, prev)
+		}
+		prev = item
+	}
+
+	q = ThenByDescending(generics.OrderedCompare[int], func(f foo) int {
+		return f.f1 % 10
+	})(OrderBy(generics.BoolCompare, getF2)(FromSlice(slice)))
+
+	prev = foo{f1: -1}
+	next = q.Iterate()
+	for item, ok := next(); ok; item, ok = next() {
+		if item.f2 == prev.f2 && item.f1%10 == prev.f1%10 && item.f1 < prev.f1 {
+			t.Fatalf("OrderBy().ThenByDescending() is not stable, %v emitted after %v", item, prev)
+		}
+		prev = item
+	}
+}
+
+func TestOrderByTake(t *testing.T) {
+	const n, k = 10000, 10
+	slice := make([]int, n)
+	for i := range slice {
+		slice[i] = (i * 7919) % n
+	}
+
+	compared := 0
+	q := OrderBy(func(i, j int) int {
+		compared++
+		return generics.OrderedCompare(i, j)
+	}, Self[int])(FromSlice(slice))
+
+	r := ToSlice(Take(q.Query, k))
+	if !ValidateQuery(FromSlice(r), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Take(OrderBy())=%v", r)
+	}
+	// Building the heap takes less than 2n comparisons, popping k elements
+	// about 2k log n, far from sorting the whole collection.
+	if compared > 2*n+2*k*14 {
+		t.Errorf("Take(OrderBy()) compared %d times", compared)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	slice := make([]foo, 1000)
+
+	for i := range slice {
+		slice[i].f1 = i
+		slice[i].f3 = string(rune('a' + i%5))
+	}
+
+	q := Sort(func(i, j foo) bool {
+		return i.f3 < j.f3
+	})(FromSlice(slice))
+
+	prev := foo{f1: -1}
+	next := q.Iterate()
+	for item, ok := next(); ok; item, ok = next() {
+		if item.f3 == prev.f3 && item.f1 < prev.f1 {
+			t.Fatalf("Sort() is not stable, %v emitted after %v", item, prev)
+		}
+		prev = item
+	}
+}
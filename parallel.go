@@ -0,0 +1,288 @@
+package flinx
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelQuery is the type returned from AsParallel, ParallelSelect and
+// ParallelWhere functions. Elements are processed by a bounded pool of worker
+// goroutines. It embeds Query, so it can be consumed by any other query
+// function.
+type ParallelQuery[T any] struct {
+	Query[T]
+	workers int
+	ordered bool
+}
+
+// AsParallel enables parallelization of the subsequent ParallelSelect,
+// ParallelWhere and ParallelForEach functions, using the given number of worker
+// goroutines. If workers is not positive, runtime.GOMAXPROCS(0) is used.
+//
+// The source is still pulled from a single goroutine, only the selectors and
+// predicates run in parallel. Results are emitted in source order unless
+// AsUnordered is applied.
+func AsParallel[T any](workers int) func(q Query[T]) ParallelQuery[T] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return func(q Query[T]) ParallelQuery[T] {
+		return ParallelQuery[T]{
+			Query:   q,
+			workers: workers,
+			ordered: true,
+		}
+	}
+
+}
+
+// AsUnordered lets the subsequent parallel functions emit results as soon as
+// they are ready, instead of in source order, for better throughput.
+func AsUnordered[T any](pq ParallelQuery[T]) ParallelQuery[T] {
+	pq.ordered = false
+	return pq
+}
+
+// ParallelSelect projects each element of a collection into a new form, running
+// selector on the worker goroutines of the parallel query.
+//
+// If selector panics, the panic is propagated to the goroutine iterating over
+// the result and the remaining work is abandoned.
+func ParallelSelect[T, V any](selector func(T) V) func(pq ParallelQuery[T]) ParallelQuery[V] {
+	return func(pq ParallelQuery[T]) ParallelQuery[V] {
+		return ParallelQuery[V]{
+			Query: Query[V]{
+				Iterate: func() Iterator[V] {
+					return parallelIterate(pq, func(item T) (V, bool) {
+						return selector(item), true
+					})
+				},
+			},
+			workers: pq.workers,
+			ordered: pq.ordered,
+		}
+	}
+
+}
+
+// ParallelWhere filters a collection of values based on predicates, running them
+// on the worker goroutines of the parallel query.
+//
+// If a predicate panics, the panic is propagated to the goroutine iterating over
+// the result and the remaining work is abandoned.
+func ParallelWhere[T any](predicates ...func(T) bool) func(pq ParallelQuery[T]) ParallelQuery[T] {
+	predicate := Predicates(predicates...)
+	return func(pq ParallelQuery[T]) ParallelQuery[T] {
+		return ParallelQuery[T]{
+			Query: Query[T]{
+				Iterate: func() Iterator[T] {
+					return parallelIterate(pq, func(item T) (T, bool) {
+						return item, predicate(item)
+					})
+				},
+			},
+			workers: pq.workers,
+			ordered: pq.ordered,
+		}
+	}
+
+}
+
+// ParallelForEach performs the specified action on each element of a
+// collection, running it on the worker goroutines of the parallel query. The
+// actions run in no particular order and ParallelForEach returns once all of
+// them are done.
+//
+// If action panics, the panic is propagated to the caller and the remaining
+// work is abandoned.
+func ParallelForEach[T any](action func(T)) func(pq ParallelQuery[T]) {
+	return func(pq ParallelQuery[T]) {
+		next := parallelIterate(AsUnordered(pq), func(item T) (struct{}, bool) {
+			action(item)
+			return struct{}{}, false
+		})
+
+		for _, ok := next(); ok; _, ok = next() {
+		}
+	}
+
+}
+
+type parallelResult[V any] struct {
+	item     V
+	keep     bool
+	panicked bool
+	panicVal any
+}
+
+type parallelJob[T, V any] struct {
+	item T
+	out  chan parallelResult[V]
+}
+
+func parallelCall[T, V any](fn func(T) (V, bool), item T) (r parallelResult[V]) {
+	defer func() {
+		if p := recover(); p != nil {
+			r = parallelResult[V]{panicked: true, panicVal: p}
+		}
+	}()
+
+	r.item, r.keep = fn(item)
+	return
+}
+
+// parallelIterate pulls the source on a feeder goroutine and fans the items out
+// to pq.workers goroutines running fn. fn returns the projected item and whether
+// it should be emitted.
+func parallelIterate[T, V any](pq ParallelQuery[T], fn func(T) (V, bool)) Iterator[V] {
+	// upstream collects the releasers of the source, they are released by the
+	// feeder goroutine once it stops pulling, since the source must not be
+	// stopped while the feeder is pulling it.
+	upstream := &releaseScope{}
+	var next Iterator[T]
+	upstream.run(func() {
+		next = pq.Query.Iterate()
+	})
+	workers := pq.workers
+	done := make(chan struct{})
+	r := newReleaser(func() {
+		close(done)
+	})
+
+	jobs := make(chan parallelJob[T, V], workers)
+	// pull feeds the jobs channel, a panic of the source is reported through
+	// report instead of crashing the feeder goroutine.
+	pull := func(enqueue func(item T) bool, report func(p any)) {
+		defer close(jobs)
+		defer upstream.release()
+		defer func() {
+			if p := recover(); p != nil {
+				report(p)
+			}
+		}()
+
+		upstream.run(func() {
+			for item, ok := next(); ok; item, ok = next() {
+				if !enqueue(item) {
+					return
+				}
+			}
+		})
+	}
+
+	var receive func() (parallelResult[V], bool)
+	if pq.ordered {
+		futures := make(chan chan parallelResult[V], workers)
+		go func() {
+			defer close(futures)
+			pull(func(item T) bool {
+				out := make(chan parallelResult[V], 1)
+				select {
+				case futures <- out:
+				case <-done:
+					return false
+				}
+				select {
+				case jobs <- parallelJob[T, V]{item: item, out: out}:
+					return true
+				case <-done:
+					return false
+				}
+			}, func(p any) {
+				out := make(chan parallelResult[V], 1)
+				out <- parallelResult[V]{panicked: true, panicVal: p}
+				select {
+				case futures <- out:
+				case <-done:
+				}
+			})
+		}()
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				for job := range jobs {
+					job.out <- parallelCall(fn, job.item)
+				}
+			}()
+		}
+
+		receive = func() (parallelResult[V], bool) {
+			out, ok := <-futures
+			if !ok {
+				return parallelResult[V]{}, false
+			}
+			return <-out, true
+		}
+	} else {
+		results := make(chan parallelResult[V], workers)
+		send := func(res parallelResult[V]) bool {
+			select {
+			case results <- res:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(workers + 1)
+		go func() {
+			defer wg.Done()
+			pull(func(item T) bool {
+				select {
+				case jobs <- parallelJob[T, V]{item: item}:
+					return true
+				case <-done:
+					return false
+				}
+			}, func(p any) {
+				send(parallelResult[V]{panicked: true, panicVal: p})
+			})
+		}()
+
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				for job := range jobs {
+					if !send(parallelCall(fn, job.item)) {
+						return
+					}
+				}
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		receive = func() (parallelResult[V], bool) {
+			res, ok := <-results
+			return res, ok
+		}
+	}
+
+	finished := false
+
+	return func() (item V, ok bool) {
+		for !finished {
+			res, more := receive()
+			if !more {
+				finished = true
+				r.release()
+				return
+			}
+			if res.panicked {
+				finished = true
+				r.release()
+				panic(res.panicVal)
+			}
+			if res.keep {
+				return res.item, true
+			}
+		}
+
+		return
+	}
+}
@@ -0,0 +1,100 @@
+package flinx
+
+import (
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParallelSelect(t *testing.T) {
+	want := ToSlice(Select(func(i int) int { return i * i })(Range(0, 1000)))
+
+	q := ParallelSelect(func(i int) int {
+		if i%7 == 0 {
+			time.Sleep(time.Microsecond)
+		}
+		return i * i
+	})(AsParallel[int](8)(Range(0, 1000)))
+	assert.DeepEqual(t, ToSlice(q.Query), want)
+
+	unordered := ToSlice(ParallelSelect(func(i int) int {
+		return i * i
+	})(AsUnordered(AsParallel[int](8)(Range(0, 1000)))).Query)
+	sort.Ints(unordered)
+	assert.DeepEqual(t, unordered, want)
+}
+
+func TestParallelWhere(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+	want := ToSlice(Where(even)(Range(0, 1000)))
+
+	q := ParallelWhere(even)(AsParallel[int](0)(Range(0, 1000)))
+	assert.DeepEqual(t, ToSlice(q.Query), want)
+
+	q = ParallelWhere(even)(AsUnordered(AsParallel[int](4)(Range(0, 1000))))
+	unordered := ToSlice(q.Query)
+	sort.Ints(unordered)
+	assert.DeepEqual(t, unordered, want)
+}
+
+func TestParallelForEach(t *testing.T) {
+	var sum int64
+	ParallelForEach(func(i int) {
+		atomic.AddInt64(&sum, int64(i))
+	})(AsParallel[int](4)(Range(1, 100)))
+	assert.Equal(t, sum, int64(5050))
+}
+
+func TestParallelPanic(t *testing.T) {
+	panicky := func(i int) int {
+		if i == 50 {
+			panic("boom")
+		}
+		return i
+	}
+
+	for _, pq := range []ParallelQuery[int]{
+		AsParallel[int](4)(Range(0, 100)),
+		AsUnordered(AsParallel[int](4)(Range(0, 100))),
+	} {
+		func() {
+			defer func() {
+				assert.Equal(t, recover(), "boom")
+			}()
+			ToSlice(ParallelSelect(panicky)(pq).Query)
+			t.Errorf("ParallelSelect() expected to panic")
+		}()
+	}
+
+	func() {
+		defer func() {
+			assert.Equal(t, recover(), "boom")
+		}()
+		ParallelForEach(func(i int) { panicky(i) })(AsParallel[int](4)(Range(0, 100)))
+		t.Errorf("ParallelForEach() expected to panic")
+	}()
+}
+
+func TestParallelAbandoned(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	func() {
+		q := ParallelSelect(func(i int) int { return i })(AsParallel[int](8)(Range(0, 1e9)))
+		first, ok := First(q.Query)
+		assert.Assert(t, ok)
+		assert.Equal(t, first, 0)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("abandoned parallel query leaked %d goroutines", runtime.NumGoroutine()-before)
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
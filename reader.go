@@ -0,0 +1,117 @@
+package flinx
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// FromScanner initializes a fallible query with passed scanner, linq iterates
+// over the tokens returned by its Text method, and reports the error returned
+// by its Err method. The scanner is read lazily, and only once: iterating the
+// query again continues where the previous iteration stopped.
+func FromScanner(s *bufio.Scanner) TryQuery[string] {
+	return TryQuery[string]{
+		Iterate: func() TryIterator[string] {
+			return func() (item string, ok bool, err error) {
+				if !s.Scan() {
+					return "", false, s.Err()
+				}
+				return s.Text(), true, nil
+			}
+		},
+	}
+}
+
+// FromLines initializes a fallible query with passed reader, linq iterates
+// over its lines, without their end-of-line marker "\n" or "\r\n". The last
+// line is emitted even if it has no end-of-line marker, and lines can be of
+// any length. Read errors other than io.EOF end the iteration and are
+// reported by the terminal functions.
+//
+// The reader is read lazily through a buffer, so readers larger than memory
+// can be processed, and only once: iterating the query again continues where
+// the previous iteration stopped.
+func FromLines(r io.Reader) TryQuery[string] {
+	return readDelimited(bufio.NewReader(r), '\n', func(s string) string {
+		s = strings.TrimSuffix(s, "\n")
+		return strings.TrimSuffix(s, "\r")
+	})
+}
+
+// FromDelimited initializes a fallible query with passed reader, linq iterates
+// over the strings separated by sep, without sep. The last string is emitted
+// only if it is not empty. It reads the reader like FromLines does.
+func FromDelimited(r io.Reader, sep byte) TryQuery[string] {
+	return readDelimited(bufio.NewReader(r), sep, func(s string) string {
+		return strings.TrimSuffix(s, string(sep))
+	})
+}
+
+func readDelimited(r *bufio.Reader, sep byte, trim func(string) string) TryQuery[string] {
+	// The reader is shared by all the iterations, so is the first error.
+	var failed error
+
+	return TryQuery[string]{
+		Iterate: func() TryIterator[string] {
+			return func() (item string, ok bool, err error) {
+				if failed != nil {
+					return "", false, failed
+				}
+
+				s, err := r.ReadString(sep)
+				if err == nil || err == io.EOF && s != "" {
+					return trim(s), true, nil
+				}
+				if err != io.EOF {
+					failed = err
+					return "", false, err
+				}
+				return "", false, nil
+			}
+		},
+	}
+}
+
+// FromRunes initializes a fallible query with passed reader, linq iterates
+// over its UTF-8 encoded runes. Invalid encodings are emitted as
+// utf8.RuneError. It reads the reader like FromLines does.
+func FromRunes(r io.Reader) TryQuery[rune] {
+	br := bufio.NewReader(r)
+	return readFrom(func() (rune, error) {
+		c, _, err := br.ReadRune()
+		return c, err
+	})
+}
+
+// FromBytes initializes a fallible query with passed reader, linq iterates
+// over its bytes. It reads the reader like FromLines does.
+func FromBytes(r io.Reader) TryQuery[byte] {
+	return readFrom(bufio.NewReader(r).ReadByte)
+}
+
+func readFrom[T any](read func() (T, error)) TryQuery[T] {
+	// The reader is shared by all the iterations, so is the first error.
+	var failed error
+
+	return TryQuery[T]{
+		Iterate: func() TryIterator[T] {
+			return func() (item T, ok bool, err error) {
+				if failed != nil {
+					return item, false, failed
+				}
+
+				if item, err = read(); err == nil {
+					return item, true, nil
+				}
+
+				var zero T
+				if err != io.EOF {
+					failed = err
+					return zero, false, err
+				}
+				return zero, false, nil
+			}
+		},
+	}
+}
@@ -0,0 +1,174 @@
+package flinx
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"gotest.tools/v3/assert"
+)
+
+var errRead = errors.New("read failed")
+
+// countingReader generates size bytes of lines of the form "xxx...\n", and
+// records how many bytes were read.
+type countingReader struct {
+	size, read int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	if r.read >= r.size {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for ; n < len(p) && r.read < r.size; n++ {
+		if r.read%10 == 9 {
+			p[n] = '\n'
+		} else {
+			p[n] = 'x'
+		}
+		r.read++
+	}
+	return n, nil
+}
+
+func TestFromLines(t *testing.T) {
+	tests := []struct {
+		input  string
+		output []string
+	}{
+		{"a\nb\r\nc", []string{"a", "b", "c"}},
+		{"a\n\nb\n", []string{"a", "", "b"}},
+		{"\n", []string{""}},
+		{"", []string{}},
+	}
+
+	for _, test := range tests {
+		r, err := TryToSlice(FromLines(strings.NewReader(test.input)))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, r, test.output)
+	}
+
+	long := strings.Repeat("y", 3*bufio.MaxScanTokenSize)
+	r, err := TryToSlice(FromLines(strings.NewReader(long + "\nz")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []string{long, "z"})
+}
+
+func TestFromLinesLazy(t *testing.T) {
+	source := &countingReader{size: 1 << 30}
+	lines := FromLines(source)
+
+	first, ok, err := TryFirst(lines)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, first, "xxxxxxxxx")
+	assert.Assert(t, source.read < 1<<20, source.read)
+
+	next := lines.Iterate()
+	for i := 0; i < 1000; i++ {
+		_, ok, err := next()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+	}
+	assert.Assert(t, source.read < 1<<20, source.read)
+
+	// The next iteration continues after the first line.
+	lines = FromLines(strings.NewReader("a\nb\nc"))
+	first, _, _ = TryFirst(lines)
+	assert.Equal(t, first, "a")
+	r, err := TryToSlice(lines)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []string{"b", "c"})
+}
+
+func TestFromLinesError(t *testing.T) {
+	source := io.MultiReader(strings.NewReader("a\nb\nc"), iotest.ErrReader(errRead))
+	next := FromLines(source).Iterate()
+
+	for _, want := range []string{"a", "b"} {
+		item, ok, err := next()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.Equal(t, item, want)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, ok, err := next()
+		assert.Assert(t, !ok)
+		assert.ErrorIs(t, err, errRead)
+	}
+
+	// The error sticks when the query is iterated again, even if the reader
+	// would succeed afterwards.
+	q := FromLines(iotest.TimeoutReader(strings.NewReader("a\nb\n")))
+	for i := 0; i < 2; i++ {
+		r, err := TryToSlice(q)
+		assert.Assert(t, r == nil)
+		assert.ErrorIs(t, err, iotest.ErrTimeout)
+	}
+}
+
+func TestFromScanner(t *testing.T) {
+	s := bufio.NewScanner(strings.NewReader("one two  three"))
+	s.Split(bufio.ScanWords)
+
+	r, err := TryToSlice(FromScanner(s))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []string{"one", "two", "three"})
+
+	s = bufio.NewScanner(io.MultiReader(strings.NewReader("a\nb\n"), iotest.ErrReader(errRead)))
+	r, err = TryToSlice(FromScanner(s))
+	assert.Assert(t, r == nil)
+	assert.ErrorIs(t, err, errRead)
+}
+
+func TestFromDelimited(t *testing.T) {
+	tests := []struct {
+		input  string
+		output []string
+	}{
+		{"a,b,,c", []string{"a", "b", "", "c"}},
+		{"a,b,", []string{"a", "b"}},
+		{"a\nb", []string{"a\nb"}},
+		{"", []string{}},
+	}
+
+	for _, test := range tests {
+		r, err := TryToSlice(FromDelimited(strings.NewReader(test.input), ','))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, r, test.output)
+	}
+
+	r, err := TryToSlice(FromDelimited(iotest.OneByteReader(strings.NewReader("x\x00y\x00")), 0))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []string{"x", "y"})
+}
+
+func TestFromRunes(t *testing.T) {
+	r, err := TryToSlice(FromRunes(iotest.OneByteReader(strings.NewReader("héllo, 世界"))))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []rune("héllo, 世界"))
+
+	r, err = TryToSlice(FromRunes(strings.NewReader("a\xffb")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []rune{'a', utf8.RuneError, 'b'})
+
+	r, err = TryToSlice(FromRunes(io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errRead))))
+	assert.Assert(t, r == nil)
+	assert.ErrorIs(t, err, errRead)
+}
+
+func TestFromBytes(t *testing.T) {
+	r, err := TryToSlice(FromBytes(strings.NewReader("ab\x00")))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []byte("ab\x00"))
+
+	_, err = TryToSlice(FromBytes(iotest.ErrReader(errRead)))
+	assert.ErrorIs(t, err, errRead)
+}
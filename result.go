@@ -5,6 +5,10 @@ import (
 
 	"golang.org/x/exp/constraints"
 	_ "golang.org/x/exp/constraints"
+
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashmap"
+	"github.com/kom0055/go-flinx/hashset"
 )
 
 // All determines whether all elements of a collection satisfy a condition.
@@ -68,6 +72,16 @@ func Average[T constraints.Integer | constraints.Float](q Query[T]) (r float64)
 
 }
 
+// AverageBy computes the average of the numeric values obtained by invoking
+// selector on each element of a collection. Method returns NaN if collection
+// contains no elements.
+func AverageBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	return func(q Query[T]) float64 {
+		return Average(Select(selector)(q))
+	}
+
+}
+
 // Contains determines whether a collection contains a specified element.
 func Contains[T comparable](value T) func(q Query[T]) bool {
 	return func(q Query[T]) bool {
@@ -84,6 +98,23 @@ func Contains[T comparable](value T) func(q Query[T]) bool {
 
 }
 
+// ContainsUsing determines whether a collection contains a specified element,
+// using comparer to find equal elements.
+func ContainsUsing[T any](value T, comparer generics.EqualityComparer[T]) func(q Query[T]) bool {
+	return func(q Query[T]) bool {
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			if comparer.Equals(item, value) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+}
+
 // Count returns the number of elements in a collection.
 func Count[T any](q Query[T]) (r int) {
 	next := q.Iterate()
@@ -112,6 +143,22 @@ func CountWith[T any](predicates ...func(T) bool) func(q Query[T]) (r int) {
 
 }
 
+// CountBy returns the number of elements of a collection for each key
+// obtained by invoking keySelector on the elements.
+func CountBy[T any, K comparable](keySelector func(T) K) func(q Query[T]) map[K]int {
+	return func(q Query[T]) map[K]int {
+		next := q.Iterate()
+		r := make(map[K]int)
+
+		for item, ok := next(); ok; item, ok = next() {
+			r[keySelector(item)]++
+		}
+
+		return r
+	}
+
+}
+
 // First returns the first element of a collection.
 func First[T any](q Query[T]) (T, bool) {
 	return q.Iterate()()
@@ -224,6 +271,31 @@ func Max[T any](compare func(t1, t2 T) int) func(q Query[T]) (r T, exist bool) {
 
 }
 
+// MaxBy returns the element of a collection having the maximum key, the key
+// being obtained by invoking selector once on each element. The first such
+// element is returned if several elements have the maximum key.
+func MaxBy[T, K any](selector func(T) K, compare func(k1, k2 K) int) func(q Query[T]) (r T, exist bool) {
+	return func(q Query[T]) (r T, exist bool) {
+		next := q.Iterate()
+		item, ok := next()
+		if !ok {
+			return
+		}
+
+		r, exist = item, true
+		key := selector(item)
+
+		for item, ok := next(); ok; item, ok = next() {
+			if k := selector(item); compare(k, key) > 0 {
+				r, key = item, k
+			}
+		}
+
+		return
+	}
+
+}
+
 // Min returns the minimum value in a collection of values.
 func Min[T any](compare func(t1, t2 T) int) func(q Query[T]) (r T, exist bool) {
 	return func(q Query[T]) (r T, exist bool) {
@@ -248,6 +320,57 @@ func Min[T any](compare func(t1, t2 T) int) func(q Query[T]) (r T, exist bool) {
 
 }
 
+// MinBy returns the element of a collection having the minimum key, the key
+// being obtained by invoking selector once on each element. The first such
+// element is returned if several elements have the minimum key.
+func MinBy[T, K any](selector func(T) K, compare func(k1, k2 K) int) func(q Query[T]) (r T, exist bool) {
+	return func(q Query[T]) (r T, exist bool) {
+		next := q.Iterate()
+		item, ok := next()
+		if !ok {
+			return
+		}
+
+		r, exist = item, true
+		key := selector(item)
+
+		for item, ok := next(); ok; item, ok = next() {
+			if k := selector(item); compare(k, key) < 0 {
+				r, key = item, k
+			}
+		}
+
+		return
+	}
+
+}
+
+// MinMax returns both the minimum and the maximum values in a collection of
+// values, iterating over it once. The first such element is returned for each
+// if several elements are equal.
+func MinMax[T any](compare func(t1, t2 T) int) func(q Query[T]) (min, max T, exist bool) {
+	return func(q Query[T]) (min, max T, exist bool) {
+		next := q.Iterate()
+		item, ok := next()
+		if !ok {
+			return
+		}
+
+		min, max, exist = item, item, true
+
+		for item, ok := next(); ok; item, ok = next() {
+			if compare(item, min) < 0 {
+				min = item
+			} else if compare(item, max) > 0 {
+				max = item
+			}
+		}
+
+		return
+	}
+
+}
+
 // Results iterates over a collection and returnes slice of interfaces
 func Results[T any](q Query[T]) (r []T) {
 	next := q.Iterate()
@@ -335,6 +458,16 @@ func Sum[T constraints.Integer | constraints.Float](q Query[T]) (r T) {
 	return
 }
 
+// SumBy computes the sum of the numeric values obtained by invoking selector
+// on each element of a collection. Method returns zero if collection contains
+// no elements.
+func SumBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) N {
+	return func(q Query[T]) N {
+		return Sum(Select(selector)(q))
+	}
+
+}
+
 // ToChannel iterates over a collection and outputs each element to a channel,
 // then closes it.
 func ToChannel[T any](q Query[T], result chan<- T) {
@@ -393,6 +526,89 @@ func ToMapBy[K comparable, V, T any](keySelector func(T) K, valueSelector func(T
 
 }
 
+// ToSet iterates over a collection and saves the results in a hashset.Any,
+// discarding duplicates.
+func ToSet[K comparable](q Query[K]) hashset.Any[K] {
+	s := hashset.NewAny[K]()
+	next := q.Iterate()
+
+	for item, ok := next(); ok; item, ok = next() {
+		s.Insert(item)
+	}
+	return s
+}
+
+// ToBiMap iterates over a collection and populates a hashmap.BiMap with the
+// keys and values generated by keySelector and valueSelector. Later elements
+// replace the mappings of earlier elements with the same key or value.
+func ToBiMap[K, V comparable, T any](keySelector func(T) K, valueSelector func(T) V) func(q Query[T]) *hashmap.BiMap[K, V] {
+	return func(q Query[T]) *hashmap.BiMap[K, V] {
+		m := hashmap.NewBiMap[K, V]()
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			m.Put(keySelector(item), valueSelector(item))
+		}
+		return m
+	}
+
+}
+
+// ToMultiMap iterates over a collection and populates a hashmap.MultiMap with
+// the keys and values generated by keySelector and valueSelector, keeping every
+// value of each key.
+func ToMultiMap[K comparable, V, T any](keySelector func(T) K, valueSelector func(T) V) func(q Query[T]) *hashmap.MultiMap[K, V] {
+	return func(q Query[T]) *hashmap.MultiMap[K, V] {
+		m := hashmap.NewMultiMap[K, V]()
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			m.Put(keySelector(item), valueSelector(item))
+		}
+		return m
+	}
+
+}
+
+// ToOrderedMap iterates over a collection and populates a hashmap.OrderedMap
+// with the keys and values generated by keySelector and valueSelector. Keys are
+// ordered by their first occurrence, and later elements replace the values of
+// earlier elements with the same key.
+func ToOrderedMap[K comparable, V, T any](keySelector func(T) K, valueSelector func(T) V) func(q Query[T]) *hashmap.OrderedMap[K, V] {
+	return func(q Query[T]) *hashmap.OrderedMap[K, V] {
+		m := hashmap.NewOrderedMap[K, V]()
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			m.Set(keySelector(item), valueSelector(item))
+		}
+		return m
+	}
+
+}
+
+// ToOrderedSet iterates over a collection and saves the results in a
+// hashset.Ordered, discarding duplicates and keeping the order of the first
+// occurrences.
+func ToOrderedSet[K comparable](q Query[K]) *hashset.Ordered[K] {
+	s := hashset.NewOrdered[K]()
+	next := q.Iterate()
+
+	for item, ok := next(); ok; item, ok = next() {
+		s.Insert(item)
+	}
+	return s
+}
+
+// ToSortedSet iterates over a collection and saves the results in a
+// hashset.Sorted ordered by compare, discarding duplicates.
+func ToSortedSet[K any](compare func(K, K) int) func(q Query[K]) *hashset.Sorted[K] {
+	return func(q Query[K]) *hashset.Sorted[K] {
+		return hashset.NewSorted(compare, ToSlice(q)...)
+	}
+
+}
+
 // ToSlice iterates over a collection and saves the results in the slice pointed
 // by v. It overwrites the existing slice, starting from index 0.
 //
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashset"
 	"gotest.tools/v3/assert"
 )
 
@@ -137,6 +138,17 @@ func TestAverageForNaN(t *testing.T) {
 	}
 }
 
+func TestAverageBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 2}, {f1: 6}}
+
+	if r := AverageBy(getF1)(FromSlice(input)); r != 3 {
+		t.Errorf("From(%v).AverageBy()=%v expected %v", input, r, 3)
+	}
+	if r := AverageBy(getF1)(FromSlice([]foo{})); !math.IsNaN(r) {
+		t.Errorf("From([]foo{}).AverageBy()=%v expected %v", r, math.NaN())
+	}
+}
+
 func TestContains(t *testing.T) {
 	{
 		tests := []struct {
@@ -186,6 +198,20 @@ func TestContains(t *testing.T) {
 	}
 }
 
+func TestContainsUsing(t *testing.T) {
+	input := [][]int{{1}, {2, 3}}
+
+	if r := ContainsUsing([]int{2, 3}, sliceComparer)(FromSlice(input)); !r {
+		t.Errorf("From(%v).ContainsUsing(%v)=%v expected %v", input, []int{2, 3}, r, true)
+	}
+	if r := ContainsUsing([]int{3, 2}, sliceComparer)(FromSlice(input)); r {
+		t.Errorf("From(%v).ContainsUsing(%v)=%v expected %v", input, []int{3, 2}, r, false)
+	}
+	if r := ContainsUsing("GO", generics.CaseInsensitiveEqualityComparer())(FromSlice([]string{"go"})); !r {
+		t.Errorf("From(%v).ContainsUsing(%v)=%v expected %v", []string{"go"}, "GO", r, true)
+	}
+}
+
 func TestCount(t *testing.T) {
 	{
 		tests := []struct {
@@ -253,6 +279,14 @@ func TestCountWith(t *testing.T) {
 	}
 }
 
+func TestCountBy(t *testing.T) {
+	input := []string{"a", "bb", "cc", "d", "eee"}
+	want := map[int]int{1: 2, 2: 2, 3: 1}
+
+	assert.DeepEqual(t, CountBy(func(s string) int { return len(s) })(FromSlice(input)), want)
+	assert.DeepEqual(t, CountBy(getF2)(FromSlice([]foo{})), map[bool]int{})
+}
+
 func TestFirst(t *testing.T) {
 	tests := []struct {
 		input []int
@@ -384,6 +418,17 @@ func TestMax(t *testing.T) {
 	}
 }
 
+func TestMaxBy(t *testing.T) {
+	input := []foo{{f1: 1, f3: "a"}, {f1: 3, f3: "b"}, {f1: 2, f3: "c"}, {f1: 3, f3: "d"}}
+
+	r, ok := MaxBy(getF1, generics.NumericCompare[int])(FromSlice(input))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, r, input[1], cmpFoo)
+
+	_, ok = MaxBy(getF1, generics.NumericCompare[int])(FromSlice([]foo{}))
+	assert.Assert(t, !ok)
+}
+
 func TestMin(t *testing.T) {
 	tests := []struct {
 		input []int
@@ -401,6 +446,36 @@ func TestMin(t *testing.T) {
 	}
 }
 
+func TestMinBy(t *testing.T) {
+	input := []foo{{f1: 2, f3: "a"}, {f1: 1, f3: "b"}, {f1: 3, f3: "c"}, {f1: 1, f3: "d"}}
+
+	r, ok := MinBy(getF1, generics.NumericCompare[int])(FromSlice(input))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, r, input[1], cmpFoo)
+
+	_, ok = MinBy(getF1, generics.NumericCompare[int])(FromSlice([]foo{}))
+	assert.Assert(t, !ok)
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		input []int
+		want  []any
+	}{
+		{[]int{2, 1, 4, 3, 0}, []any{0, 4, true}},
+		{[]int{4, 3, 2, 1}, []any{1, 4, true}},
+		{[]int{1}, []any{1, 1, true}},
+		{[]int{}, []any{0, 0, false}},
+	}
+
+	for _, test := range tests {
+		min, max, ok := MinMax(generics.NumericCompare[int])(FromSlice(test.input))
+		if min != test.want[0] || max != test.want[1] || ok != test.want[2] {
+			t.Errorf("From(%v).MinMax()=%v %v %v expected %v", test.input, min, max, ok, test.want)
+		}
+	}
+}
+
 func TestResults(t *testing.T) {
 	input := []int{1, 2, 3}
 	want := []int{1, 2, 3}
@@ -517,6 +592,20 @@ func TestSumFloats(t *testing.T) {
 	}
 }
 
+func TestSumBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 2}, {f1: 6}}
+
+	if r := SumBy(getF1)(FromSlice(input)); r != 9 {
+		t.Errorf("From(%v).SumBy()=%v expected %v", input, r, 9)
+	}
+	if r := SumBy(func(f foo) float64 { return float64(f.f1) / 2 })(FromSlice(input)); r != 4.5 {
+		t.Errorf("From(%v).SumBy()=%v expected %v", input, r, 4.5)
+	}
+	if r := SumBy(getF1)(FromSlice([]foo{})); r != 0 {
+		t.Errorf("From([]foo{}).SumBy()=%v expected %v", r, 0)
+	}
+}
+
 func TestToChannel(t *testing.T) {
 	c := make(chan int)
 	input := []int{1, 2, 3, 4, 5}
@@ -585,6 +674,39 @@ func TestToMapBy(t *testing.T) {
 	}
 }
 
+func TestToMultiMap(t *testing.T) {
+	input := []string{"apple", "avocado", "banana", "apricot"}
+	first := func(s string) byte {
+		return s[0]
+	}
+
+	m := ToMultiMap(first, Self[string])(FromSlice(input))
+	assert.DeepEqual(t, m.Keys(), []byte{'a', 'b'})
+	assert.DeepEqual(t, m.Get('a'), []string{"apple", "avocado", "apricot"})
+
+	om := ToOrderedMap(first, Self[string])(FromSlice(input))
+	assert.DeepEqual(t, om.Keys(), []byte{'a', 'b'})
+	assert.DeepEqual(t, om.Values(), []string{"apricot", "banana"})
+
+	bm := ToBiMap(first, func(s string) int {
+		return len(s)
+	})(FromSlice(input))
+	assert.Equal(t, bm.Len(), 2)
+	k, ok := bm.GetKey(6)
+	assert.Assert(t, ok)
+	assert.Equal(t, k, byte('b'))
+	v, _ := bm.Get('a')
+	assert.Equal(t, v, 7)
+}
+
+func TestToSet(t *testing.T) {
+	input := []int{3, 1, 3, 2, 1}
+
+	assert.Assert(t, ToSet(FromSlice(input)).Equal(hashset.NewAny(1, 2, 3)))
+	assert.DeepEqual(t, ToOrderedSet(FromSlice(input)).List(), []int{3, 1, 2})
+	assert.DeepEqual(t, ToSortedSet(generics.OrderedCompare[int])(FromSlice(input)).List(), []int{1, 2, 3})
+}
+
 func TestToSlice(t *testing.T) {
 	tests := []struct {
 		input  []int
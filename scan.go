@@ -0,0 +1,70 @@
+package flinx
+
+import "golang.org/x/exp/constraints"
+
+// Scan applies an accumulator function over a sequence like AggregateWithSeed
+// does, but emits every intermediate accumulator value instead of the final
+// one only.
+//
+// The value of the seed parameter is used as the initial accumulator value,
+// and is not emitted itself. Each time an element is read, f() is called with
+// the current accumulator value and the element, and its result is both
+// emitted and used as the new accumulator value, so the result has as many
+// elements as the source.
+func Scan[T, V any](seed V, f func(V, T) V) func(q Query[T]) Query[V] {
+	return func(q Query[T]) Query[V] {
+		return Query[V]{
+			Iterate: func() Iterator[V] {
+				next := q.Iterate()
+				result := seed
+
+				return func() (item V, ok bool) {
+					current, ok := next()
+					if !ok {
+						return
+					}
+
+					result = f(result, current)
+					return result, true
+				}
+			},
+		}
+	}
+
+}
+
+// RunningSum emits the cumulative sum of a collection of numeric values, that
+// is the sum of each element and all the elements before it.
+func RunningSum[T constraints.Integer | constraints.Float](q Query[T]) Query[T] {
+	return Scan(T(0), func(sum, item T) T {
+		return sum + item
+	})(q)
+}
+
+// RunningMax emits the maximum value of each element and all the elements
+// before it according to compare.
+func RunningMax[T any](compare func(t1, t2 T) int) func(q Query[T]) Query[T] {
+	return func(q Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next := q.Iterate()
+				var max T
+				started := false
+
+				return func() (item T, ok bool) {
+					item, ok = next()
+					if !ok {
+						return
+					}
+
+					if !started || compare(item, max) > 0 {
+						max = item
+						started = true
+					}
+					return max, true
+				}
+			},
+		}
+	}
+
+}
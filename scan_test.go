@@ -0,0 +1,42 @@
+package flinx
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/kom0055/go-flinx/generics"
+)
+
+func TestScan(t *testing.T) {
+	balance := Scan(100, func(balance int, tx foo) int {
+		if tx.f2 {
+			return balance + tx.f1
+		}
+		return balance - tx.f1
+	})
+	input := []foo{{f1: 20, f2: true}, {f1: 50}, {f1: 5, f2: true}}
+
+	assert.DeepEqual(t, ToSlice(balance(FromSlice(input))), []int{120, 70, 75})
+	assert.DeepEqual(t, ToSlice(balance(FromSlice([]foo{}))), []int{})
+
+	concat := Scan("", func(s string, r rune) string { return s + string(r) })
+	assert.DeepEqual(t, ToSlice(concat(FromString("abc"))), []string{"a", "ab", "abc"})
+
+	// Each iteration starts again from the seed.
+	q := concat(FromString("xy"))
+	assert.DeepEqual(t, ToSlice(q), ToSlice(q))
+}
+
+func TestRunningSum(t *testing.T) {
+	assert.DeepEqual(t, ToSlice(RunningSum(FromSlice([]int{1, 2, 3, -4}))), []int{1, 3, 6, 2})
+	assert.DeepEqual(t, ToSlice(RunningSum(FromSlice([]float64{0.5, 0.25}))), []float64{0.5, 0.75})
+	assert.DeepEqual(t, ToSlice(RunningSum(FromSlice([]int{}))), []int{})
+}
+
+func TestRunningMax(t *testing.T) {
+	runningMax := RunningMax(generics.NumericCompare[int])
+
+	assert.DeepEqual(t, ToSlice(runningMax(FromSlice([]int{-3, -5, 2, 1, 4}))), []int{-3, -3, 2, 2, 4})
+	assert.DeepEqual(t, ToSlice(runningMax(FromSlice([]int{}))), []int{})
+}
@@ -1,11 +1,22 @@
 package flinx
 
+import (
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kom0055/go-flinx/generics"
+)
+
 type foo struct {
 	f1 int
 	f2 bool
 	f3 string
 }
 
+// cmpFoo lets assert.DeepEqual compare the unexported fields of foo.
+var cmpFoo = cmp.AllowUnexported(foo{})
+
 func getF1(f foo) int {
 	return f.f1
 }
@@ -13,3 +24,12 @@ func getF1(f foo) int {
 func getF2(f foo) bool {
 	return f.f2
 }
+
+// sliceComparer compares slices of ints by their elements. Its hash only
+// depends on the length, so equal hashes are common and must be told apart by
+// Equals.
+var sliceComparer = generics.NewEqualityComparer(func(s1, s2 []int) bool {
+	return reflect.DeepEqual(s1, s2)
+}, func(s []int) uint64 {
+	return uint64(len(s))
+})
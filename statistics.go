@@ -0,0 +1,215 @@
+package flinx
+
+import (
+	"math"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Median computes the median of a collection of numeric values, the mean of
+// the two middle values if the collection has an even number of elements.
+// Method returns NaN if collection contains no elements.
+func Median[T constraints.Integer | constraints.Float](q Query[T]) float64 {
+	return MedianBy(Self[T])(q)
+}
+
+// MedianBy computes the median of the numeric values obtained by invoking
+// selector on each element of a collection.
+func MedianBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	return PercentileBy(50, selector)
+}
+
+// Percentile computes the p-th percentile of a collection of numeric values.
+// p is a percentage ranging from 0 to 100, unlike the quantiles passed to
+// ApproxQuantiles which range from 0 to 1. The percentile is linearly
+// interpolated between the two closest ranks. Method returns NaN if collection
+// contains no elements or p is out of range or NaN.
+func Percentile[T constraints.Integer | constraints.Float](p float64) func(q Query[T]) float64 {
+	return PercentileBy(p, Self[T])
+}
+
+// PercentileBy computes the p-th percentile of the numeric values obtained by
+// invoking selector on each element of a collection, p ranging from 0 to 100
+// like for Percentile.
+func PercentileBy[T any, N constraints.Integer | constraints.Float](p float64, selector func(T) N) func(q Query[T]) float64 {
+	return func(q Query[T]) float64 {
+		if !(p >= 0 && p <= 100) {
+			return math.NaN()
+		}
+
+		values := ToSlice(Select(func(t T) float64 {
+			return float64(selector(t))
+		})(q))
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		sort.Float64s(values)
+
+		rank := p / 100 * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+	}
+
+}
+
+// Variance computes the population variance of a collection of numeric values,
+// in a single pass using Welford's algorithm. Method returns NaN if collection
+// contains no elements.
+func Variance[T constraints.Integer | constraints.Float](q Query[T]) float64 {
+	return VarianceBy(Self[T])(q)
+}
+
+// VarianceBy computes the population variance of the numeric values obtained by
+// invoking selector on each element of a collection.
+func VarianceBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	return func(q Query[T]) float64 {
+		n, m2 := welford(q, selector)
+		if n == 0 {
+			return math.NaN()
+		}
+		return m2 / float64(n)
+	}
+
+}
+
+// SampleVariance computes the sample variance of a collection of numeric
+// values, using Bessel's correction. Method returns NaN if collection contains
+// less than two elements.
+func SampleVariance[T constraints.Integer | constraints.Float](q Query[T]) float64 {
+	return SampleVarianceBy(Self[T])(q)
+}
+
+// SampleVarianceBy computes the sample variance of the numeric values obtained
+// by invoking selector on each element of a collection.
+func SampleVarianceBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	return func(q Query[T]) float64 {
+		n, m2 := welford(q, selector)
+		if n < 2 {
+			return math.NaN()
+		}
+		return m2 / float64(n-1)
+	}
+
+}
+
+// StdDev computes the population standard deviation of a collection of numeric
+// values. Method returns NaN if collection contains no elements.
+func StdDev[T constraints.Integer | constraints.Float](q Query[T]) float64 {
+	return math.Sqrt(Variance(q))
+}
+
+// StdDevBy computes the population standard deviation of the numeric values
+// obtained by invoking selector on each element of a collection.
+func StdDevBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	variance := VarianceBy(selector)
+	return func(q Query[T]) float64 {
+		return math.Sqrt(variance(q))
+	}
+
+}
+
+// SampleStdDev computes the sample standard deviation of a collection of
+// numeric values. Method returns NaN if collection contains less than two
+// elements.
+func SampleStdDev[T constraints.Integer | constraints.Float](q Query[T]) float64 {
+	return math.Sqrt(SampleVariance(q))
+}
+
+// SampleStdDevBy computes the sample standard deviation of the numeric values
+// obtained by invoking selector on each element of a collection.
+func SampleStdDevBy[T any, N constraints.Integer | constraints.Float](selector func(T) N) func(q Query[T]) float64 {
+	variance := SampleVarianceBy(selector)
+	return func(q Query[T]) float64 {
+		return math.Sqrt(variance(q))
+	}
+
+}
+
+// welford returns the number of values and the sum of squared differences from
+// their mean, computed in a single numerically stable pass.
+func welford[T any, N constraints.Integer | constraints.Float](q Query[T], selector func(T) N) (n int, m2 float64) {
+	mean := 0.
+	next := q.Iterate()
+
+	for item, ok := next(); ok; item, ok = next() {
+		x := float64(selector(item))
+		n++
+		delta := x - mean
+		mean += delta / float64(n)
+		m2 += delta * (x - mean)
+	}
+
+	return
+}
+
+// Mode returns the most frequent element of a collection. If several elements
+// are the most frequent, the one appearing first in the collection is returned.
+func Mode[T comparable](q Query[T]) (T, bool) {
+	return ModeBy(Self[T])(q)
+}
+
+// ModeBy returns the most frequent value obtained by invoking selector on each
+// element of a collection. If several values are the most frequent, the one
+// appearing first in the collection is returned.
+func ModeBy[T any, K comparable](selector func(T) K) func(q Query[T]) (K, bool) {
+	return func(q Query[T]) (r K, found bool) {
+		counts := map[K]int{}
+		first := map[K]int{}
+		best, bestFirst := 0, 0
+		index := 0
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			key := selector(item)
+			count := counts[key] + 1
+			counts[key] = count
+			if count == 1 {
+				first[key] = index
+			}
+			index++
+
+			if count > best || (count == best && first[key] < bestFirst) {
+				r, found = key, true
+				best, bestFirst = count, first[key]
+			}
+		}
+
+		return
+	}
+
+}
+
+// Histogram counts the numeric values of a collection falling in each bucket
+// delimited by bounds, which must be sorted in ascending order. The result has
+// len(bounds)+1 counts: the first one for values less than bounds[0], the i-th
+// one for values greater than or equal to bounds[i-1] and less than bounds[i],
+// and the last one for values greater than or equal to the last bound. NaN
+// values are not counted.
+func Histogram[T constraints.Integer | constraints.Float](bounds ...float64) func(q Query[T]) []int {
+	return HistogramBy(Self[T], bounds...)
+}
+
+// HistogramBy counts the numeric values obtained by invoking selector on each
+// element of a collection falling in each bucket delimited by bounds.
+func HistogramBy[T any, N constraints.Integer | constraints.Float](selector func(T) N, bounds ...float64) func(q Query[T]) []int {
+	return func(q Query[T]) []int {
+		counts := make([]int, len(bounds)+1)
+		next := q.Iterate()
+
+		for item, ok := next(); ok; item, ok = next() {
+			x := float64(selector(item))
+			if math.IsNaN(x) {
+				continue
+			}
+
+			counts[sort.Search(len(bounds), func(i int) bool {
+				return x < bounds[i]
+			})]++
+		}
+
+		return counts
+	}
+
+}
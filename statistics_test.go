@@ -0,0 +1,90 @@
+package flinx
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func assertFloat(t *testing.T, got, want float64) {
+	t.Helper()
+	if math.IsNaN(want) {
+		if !math.IsNaN(got) {
+			t.Errorf("got %v expected NaN", got)
+		}
+		return
+	}
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v expected %v", got, want)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	assertFloat(t, Median(FromSlice([]int{5, 1, 3})), 3)
+	assertFloat(t, Median(FromSlice([]int{4, 1, 3, 2})), 2.5)
+	assertFloat(t, Median(FromSlice([]float64{0.5})), 0.5)
+	assertFloat(t, Median(FromSlice([]int{})), math.NaN())
+	assertFloat(t, MedianBy(getF1)(FromSlice([]foo{{f1: 10}, {f1: 30}, {f1: 20}})), 20)
+}
+
+func TestPercentile(t *testing.T) {
+	input := FromSlice([]int{15, 20, 35, 40, 50})
+
+	assertFloat(t, Percentile[int](0)(input), 15)
+	assertFloat(t, Percentile[int](25)(input), 20)
+	assertFloat(t, Percentile[int](40)(input), 29)
+	assertFloat(t, Percentile[int](100)(input), 50)
+	assertFloat(t, Percentile[int](101)(input), math.NaN())
+	assertFloat(t, Percentile[int](-1)(input), math.NaN())
+	assertFloat(t, Percentile[int](math.NaN())(input), math.NaN())
+	assertFloat(t, Percentile[int](50)(FromSlice([]int{})), math.NaN())
+	assertFloat(t, PercentileBy(90, getF1)(FromSlice([]foo{{f1: 1}, {f1: 11}})), 10)
+}
+
+func TestVariance(t *testing.T) {
+	input := FromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9})
+
+	assertFloat(t, Variance(input), 4)
+	assertFloat(t, StdDev(input), 2)
+	assertFloat(t, SampleVariance(input), 32./7)
+	assertFloat(t, SampleStdDev(input), math.Sqrt(32./7))
+
+	assertFloat(t, Variance(FromSlice([]float64{})), math.NaN())
+	assertFloat(t, Variance(FromSlice([]float64{3})), 0)
+	assertFloat(t, SampleVariance(FromSlice([]float64{3})), math.NaN())
+
+	foos := FromSlice([]foo{{f1: 1}, {f1: 3}})
+	assertFloat(t, VarianceBy(getF1)(foos), 1)
+	assertFloat(t, StdDevBy(getF1)(foos), 1)
+	assertFloat(t, SampleVarianceBy(getF1)(foos), 2)
+	assertFloat(t, SampleStdDevBy(getF1)(foos), math.Sqrt(2))
+
+	// Welford's algorithm does not lose precision on large offsets.
+	assertFloat(t, Variance(FromSlice([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16})), 22.5)
+}
+
+func TestMode(t *testing.T) {
+	r, ok := Mode(FromSlice([]int{3, 1, 2, 1, 3}))
+	assert.Assert(t, ok)
+	assert.Equal(t, r, 3)
+
+	r, ok = Mode(FromSlice([]int{1, 2, 2}))
+	assert.Assert(t, ok)
+	assert.Equal(t, r, 2)
+
+	_, ok = Mode(FromSlice([]int{}))
+	assert.Assert(t, !ok)
+
+	s, ok := ModeBy(func(f foo) string { return f.f3 })(FromSlice([]foo{{f3: "a"}, {f3: "b"}, {f3: "b"}}))
+	assert.Assert(t, ok)
+	assert.Equal(t, s, "b")
+}
+
+func TestHistogram(t *testing.T) {
+	input := FromSlice([]float64{-1, 0, 0.5, 1, 2, 9.9, 10, 100, math.NaN()})
+
+	assert.DeepEqual(t, Histogram[float64](0, 1, 10)(input), []int{1, 2, 3, 2})
+	assert.DeepEqual(t, Histogram[float64]()(input), []int{8})
+	assert.DeepEqual(t, HistogramBy(getF1, 5)(FromSlice([]foo{{f1: 1}, {f1: 5}, {f1: 6}})), []int{1, 2})
+}
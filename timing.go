@@ -0,0 +1,219 @@
+package flinx
+
+import "time"
+
+// Clock is the source of time used by the time-based query functions such as
+// BufferWithTimeOrCount or Debounce. It can be replaced by a fake clock in
+// tests, so they don't have to sleep.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event timer created by a Clock. C returns the channel on
+// which the current time is delivered once the timer fires.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// BufferWithTimeOrCount initializes a linq query with passed channel, emitting
+// the received items in slices of up to count items. A slice is emitted as
+// soon as it is full, or once d has elapsed since its first item was received,
+// whichever happens first. Empty slices are never emitted.
+//
+// The last, possibly shorter, slice is emitted when the channel is closed.
+// BufferWithTimeOrCount panics if count is not positive.
+func BufferWithTimeOrCount[T any](clock Clock, d time.Duration, count int) func(source <-chan T) Query[[]T] {
+	if count <= 0 {
+		panic("flinx: BufferWithTimeOrCount count must be positive")
+	}
+
+	return func(source <-chan T) Query[[]T] {
+		return Query[[]T]{
+			Iterate: func() Iterator[[]T] {
+				done := false
+
+				return func() (item []T, ok bool) {
+					if done {
+						return
+					}
+
+					var timeout <-chan time.Time
+					var timer Timer
+					for {
+						select {
+						case it, more := <-source:
+							if !more {
+								done = true
+								if timer != nil {
+									timer.Stop()
+								}
+								return item, len(item) > 0
+							}
+
+							if item == nil {
+								item = make([]T, 0, count)
+								timer = clock.NewTimer(d)
+								timeout = timer.C()
+							}
+							item = append(item, it)
+
+							if len(item) >= count {
+								timer.Stop()
+								return item, true
+							}
+						case <-timeout:
+							return item, true
+						}
+					}
+				}
+			},
+		}
+	}
+
+}
+
+// Throttle initializes a linq query with passed channel, emitting a received
+// item and then ignoring the items received during the following d.
+func Throttle[T any](clock Clock, d time.Duration) func(source <-chan T) Query[T] {
+	return func(source <-chan T) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				var last time.Time
+				emitted := false
+
+				return func() (item T, ok bool) {
+					for item, ok = <-source; ok; item, ok = <-source {
+						now := clock.Now()
+						if !emitted || now.Sub(last) >= d {
+							emitted = true
+							last = now
+							return
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
+
+// Debounce initializes a linq query with passed channel, emitting a received
+// item only once d has elapsed without receiving another item. Items that are
+// followed by another one within d are dropped.
+//
+// The pending item, if any, is emitted when the channel is closed.
+func Debounce[T any](clock Clock, d time.Duration) func(source <-chan T) Query[T] {
+	return func(source <-chan T) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				done := false
+
+				return func() (item T, ok bool) {
+					if done {
+						return
+					}
+
+					var timeout <-chan time.Time
+					var timer Timer
+					pending := false
+					for {
+						select {
+						case it, more := <-source:
+							if timer != nil {
+								timer.Stop()
+							}
+							if !more {
+								done = true
+								return item, pending
+							}
+
+							item, pending = it, true
+							timer = clock.NewTimer(d)
+							timeout = timer.C()
+						case <-timeout:
+							return item, true
+						}
+					}
+				}
+			},
+		}
+	}
+
+}
+
+// Sample initializes a linq query with passed channel, emitting every d the
+// most recent item received during that period. Nothing is emitted for a
+// period during which no item was received.
+//
+// The pending item, if any, is emitted when the channel is closed. The timer of
+// the current period is stopped once the channel is closed, or when the
+// iterator is abandoned.
+func Sample[T any](clock Clock, d time.Duration) func(source <-chan T) Query[T] {
+	return func(source <-chan T) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				var timer Timer
+				r := newReleaser(func() {
+					if timer != nil {
+						timer.Stop()
+					}
+				})
+				done := false
+
+				return func() (item T, ok bool) {
+					if done {
+						return
+					}
+
+					if timer == nil {
+						timer = clock.NewTimer(d)
+					}
+					pending := false
+					for {
+						select {
+						case it, more := <-source:
+							if !more {
+								done = true
+								r.release()
+								return item, pending
+							}
+
+							item, pending = it, true
+						case tick := <-timer.C():
+							timer = clock.NewTimer(d - clock.Now().Sub(tick))
+							if pending {
+								return item, true
+							}
+						}
+					}
+				}
+			},
+		}
+	}
+
+}
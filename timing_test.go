@@ -0,0 +1,232 @@
+package flinx
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// fakeClock is a Clock whose time only moves forward through Advance. Advance
+// blocks until every timer it fires has been received or stopped, so tests can
+// interleave items and ticks deterministically.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	c       chan time.Time
+	stop    chan struct{}
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time), stop: make(chan struct{})}
+	c.timers = append(c.timers, t)
+	return &fakeTimerHandle{clock: c, timer: t}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	var due, pending []*fakeTimer
+	for _, t := range c.timers {
+		switch {
+		case t.stopped:
+		case !t.at.After(now):
+			due = append(due, t)
+		default:
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	for _, t := range due {
+		select {
+		case t.c <- now:
+		case <-t.stop:
+		}
+	}
+}
+
+// running returns the number of timers neither fired nor stopped.
+func (c *fakeClock) running() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, t := range c.timers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+type fakeTimerHandle struct {
+	clock *fakeClock
+	timer *fakeTimer
+}
+
+func (h *fakeTimerHandle) C() <-chan time.Time {
+	return h.timer.c
+}
+
+func (h *fakeTimerHandle) Stop() bool {
+	h.clock.mu.Lock()
+	defer h.clock.mu.Unlock()
+	if h.timer.stopped {
+		return false
+	}
+	h.timer.stopped = true
+	close(h.timer.stop)
+	return true
+}
+
+// collect consumes q on another goroutine while produce feeds source, and
+// returns the items emitted by q.
+func collect[T, V any](q Query[V], source chan T, produce func()) []V {
+	result := make(chan []V)
+	go func() {
+		result <- ToSlice(q)
+	}()
+	produce()
+	close(source)
+	return <-result
+}
+
+func TestBufferWithTimeOrCount(t *testing.T) {
+	clock := newFakeClock()
+	source := make(chan int)
+	q := BufferWithTimeOrCount[int](clock, time.Second, 3)(source)
+
+	r := collect(q, source, func() {
+		source <- 1
+		source <- 2
+		source <- 3
+		source <- 4
+		clock.Advance(500 * time.Millisecond)
+		source <- 5
+		clock.Advance(500 * time.Millisecond)
+		clock.Advance(time.Hour)
+		source <- 6
+	})
+	assert.DeepEqual(t, r, [][]int{{1, 2, 3}, {4, 5}, {6}})
+}
+
+func TestSystemClock(t *testing.T) {
+	assert.Assert(t, !SystemClock.Now().IsZero())
+
+	timer := SystemClock.NewTimer(0)
+	<-timer.C()
+	assert.Assert(t, !timer.Stop())
+
+	assert.Assert(t, SystemClock.NewTimer(time.Hour).Stop())
+}
+
+// scriptedClock is a Clock returning the given instants, one per call to Now.
+type scriptedClock struct {
+	*fakeClock
+	instants []time.Duration
+}
+
+func (c *scriptedClock) Now() time.Time {
+	now := time.Unix(0, 0).Add(c.instants[0])
+	c.instants = c.instants[1:]
+	return now
+}
+
+func TestThrottle(t *testing.T) {
+	clock := &scriptedClock{instants: []time.Duration{
+		0,
+		500 * time.Millisecond,
+		999 * time.Millisecond,
+		time.Second,
+		1500 * time.Millisecond,
+		3 * time.Second,
+	}}
+
+	source := make(chan int, 6)
+	ToChannel(Range(1, 6), source)
+
+	q := Throttle[int](clock, time.Second)(source)
+	assert.DeepEqual(t, ToSlice(q), []int{1, 4, 6})
+}
+
+func TestDebounce(t *testing.T) {
+	clock := newFakeClock()
+	source := make(chan int)
+	q := Debounce[int](clock, time.Second)(source)
+
+	r := collect(q, source, func() {
+		source <- 1
+		clock.Advance(500 * time.Millisecond)
+		source <- 2
+		clock.Advance(500 * time.Millisecond)
+		source <- 3
+		clock.Advance(time.Second)
+		clock.Advance(time.Second)
+		source <- 4
+		source <- 5
+	})
+	assert.DeepEqual(t, r, []int{3, 5})
+}
+
+func TestSample(t *testing.T) {
+	clock := newFakeClock()
+	source := make(chan int)
+	q := Sample[int](clock, time.Second)(source)
+
+	r := collect(q, source, func() {
+		source <- 1
+		source <- 2
+		clock.Advance(time.Second)
+		clock.Advance(time.Second)
+		source <- 3
+		clock.Advance(time.Second)
+		source <- 4
+	})
+	assert.DeepEqual(t, r, []int{2, 3, 4})
+	assert.Equal(t, clock.running(), 0)
+}
+
+func TestSampleAbandoned(t *testing.T) {
+	clock := newFakeClock()
+	source := make(chan int)
+	q := Sample[int](clock, time.Second)(source)
+
+	// The iterator is abandoned after its first item, and released the way
+	// All releases it when the loop breaks.
+	first := make(chan int)
+	go func() {
+		scope := &releaseScope{}
+		var item int
+		scope.run(func() {
+			item, _ = q.Iterate()()
+		})
+		scope.release()
+		first <- item
+	}()
+
+	source <- 1
+	clock.Advance(time.Second)
+	assert.Equal(t, <-first, 1)
+	assert.Equal(t, clock.running(), 0)
+}
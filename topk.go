@@ -0,0 +1,118 @@
+package flinx
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopK returns the k greatest elements of a collection according to compare,
+// in descending order. Equal elements keep their relative order in the
+// collection, so TopK yields the same result as OrderByDescending followed by
+// Take, in O(n log k) time and O(k) memory.
+func TopK[T any](k int, compare func(T, T) int) func(q Query[T]) Query[T] {
+	return BottomK(k, func(t1, t2 T) int {
+		return compare(t2, t1)
+	})
+
+}
+
+// BottomK returns the k smallest elements of a collection according to
+// compare, in ascending order. Equal elements keep their relative order in the
+// collection, so BottomK yields the same result as OrderBy followed by Take, in
+// O(n log k) time and O(k) memory.
+func BottomK[T any](k int, compare func(T, T) int) func(q Query[T]) Query[T] {
+	return func(q Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				return FromSlice(smallestK(q, k, compare)).Iterate()
+			},
+		}
+	}
+
+}
+
+// TakeOrdered returns a specified number of contiguous elements from the start
+// of an ordered collection. It yields the same result as Take, but selects the
+// elements with a heap bounded to count elements instead of buffering the
+// whole collection, giving O(n log count) time and O(count) memory.
+func TakeOrdered[T any](oq OrderedQuery[T], count int) Query[T] {
+	if oq.cmp == nil {
+		return Take(oq.Query, count)
+	}
+
+	return BottomK(count, oq.cmp)(oq.original)
+}
+
+type rankedItem[T any] struct {
+	item  T
+	index int
+}
+
+// rankHeap is a max-heap of the best ranked items seen so far, its root being
+// the worst of them.
+type rankHeap[T any] struct {
+	items []rankedItem[T]
+	less  func(r1, r2 rankedItem[T]) bool
+}
+
+func (h *rankHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *rankHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[j], h.items[i])
+}
+
+func (h *rankHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *rankHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(rankedItem[T]))
+}
+
+func (h *rankHeap[T]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items = h.items[:last]
+	return item
+}
+
+func smallestK[T any](q Query[T], k int, compare func(T, T) int) []T {
+	if k <= 0 {
+		return nil
+	}
+
+	h := &rankHeap[T]{
+		less: func(r1, r2 rankedItem[T]) bool {
+			if c := compare(r1.item, r2.item); c != 0 {
+				return c < 0
+			}
+			return r1.index < r2.index
+		},
+	}
+
+	next := q.Iterate()
+	index := 0
+	for item, ok := next(); ok; item, ok = next() {
+		ranked := rankedItem[T]{item, index}
+		index++
+
+		if h.Len() < k {
+			heap.Push(h, ranked)
+		} else if h.less(ranked, h.items[0]) {
+			h.items[0] = ranked
+			heap.Fix(h, 0)
+		}
+	}
+
+	sort.Slice(h.items, func(i, j int) bool {
+		return h.less(h.items[i], h.items[j])
+	})
+
+	r := make([]T, len(h.items))
+	for i, ranked := range h.items {
+		r[i] = ranked.item
+	}
+	return r
+}
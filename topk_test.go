@@ -0,0 +1,55 @@
+package flinx
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kom0055/go-flinx/generics"
+	"gotest.tools/v3/assert"
+)
+
+func TestTopK(t *testing.T) {
+	input := []int{5, 1, 9, 3, 7, 9, 2}
+
+	assert.DeepEqual(t, ToSlice(TopK(3, generics.OrderedCompare[int])(FromSlice(input))), []int{9, 9, 7})
+	assert.DeepEqual(t, ToSlice(TopK(10, generics.OrderedCompare[int])(FromSlice(input))), []int{9, 9, 7, 5, 3, 2, 1})
+	assert.DeepEqual(t, ToSlice(TopK(0, generics.OrderedCompare[int])(FromSlice(input))), []int{})
+}
+
+func TestBottomK(t *testing.T) {
+	input := []int{5, 1, 9, 3, 7, 1, 2}
+
+	assert.DeepEqual(t, ToSlice(BottomK(3, generics.OrderedCompare[int])(FromSlice(input))), []int{1, 1, 2})
+	assert.DeepEqual(t, ToSlice(BottomK(3, generics.OrderedCompare[int])(FromSlice([]int{}))), []int{})
+}
+
+func TestTopKStable(t *testing.T) {
+	slice := make([]foo, 1000)
+	for i := range slice {
+		slice[i].f1 = rand.Intn(10)
+		slice[i].f3 = string(rune(i))
+	}
+
+	want := ToSlice(Take(OrderByDescending(generics.OrderedCompare[int], getF1)(FromSlice(slice)).Query, 50))
+	r := ToSlice(TopK(50, func(f1, f2 foo) int {
+		return generics.OrderedCompare(f1.f1, f2.f1)
+	})(FromSlice(slice)))
+	assert.DeepEqual(t, r, want, cmpFoo)
+}
+
+func TestTakeOrdered(t *testing.T) {
+	slice := make([]foo, 1000)
+	for i := range slice {
+		slice[i].f1 = rand.Intn(10)
+		slice[i].f2 = rand.Intn(2) == 0
+		slice[i].f3 = string(rune(i))
+	}
+
+	oq := ThenBy(generics.BoolCompare, getF2)(OrderBy(generics.OrderedCompare[int], getF1)(FromSlice(slice)))
+	for _, count := range []int{0, 1, 10, 999, 1000, 2000} {
+		assert.DeepEqual(t, ToSlice(TakeOrdered(oq, count)), ToSlice(Take(oq.Query, count)), cmpFoo)
+	}
+
+	distinct := DistinctOrderedQuery(OrderBy(generics.OrderedCompare[int], Self[int])(FromSlice([]int{3, 1, 3, 2})))
+	assert.DeepEqual(t, ToSlice(TakeOrdered(distinct, 2)), []int{1, 2})
+}
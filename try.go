@@ -0,0 +1,331 @@
+package flinx
+
+import "fmt"
+
+// TryIterator is an alias for function to iterate over data whose evaluation
+// may fail. Once err is not nil, ok is false and the iteration is over.
+type TryIterator[T any] func() (item T, ok bool, err error)
+
+// TryQuery is the type returned from fallible query functions, such as
+// TrySelect or TryWhere. It short-circuits on the first error, which is
+// reported by the terminal functions such as TryToSlice.
+type TryQuery[T any] struct {
+	Iterate func() TryIterator[T]
+
+	// indexed iterates like Iterate, and also returns the index of each
+	// element in the source collection of the pipeline, which the fallible
+	// query functions forward so that an ElementError can be traced back to
+	// the source.
+	indexed func() indexedTryIterator[T]
+}
+
+// indexedTryIterator is a TryIterator that also returns the index of each
+// element in the source collection.
+type indexedTryIterator[T any] func() (item T, index int, ok bool, err error)
+
+// newTryQuery returns a TryQuery iterating with iterate.
+func newTryQuery[T any](iterate func() indexedTryIterator[T]) TryQuery[T] {
+	return TryQuery[T]{
+		Iterate: func() TryIterator[T] {
+			next := iterate()
+
+			return func() (item T, ok bool, err error) {
+				item, _, ok, err = next()
+				return
+			}
+		},
+		indexed: iterate,
+	}
+}
+
+// iterateIndexed returns an iterator over the elements of q and their index in
+// the source collection. If q is itself the source, such as a query returned
+// by AsTry or FromLines, elements are numbered in their order.
+func (q TryQuery[T]) iterateIndexed() indexedTryIterator[T] {
+	if q.indexed != nil {
+		return q.indexed()
+	}
+
+	next := q.Iterate()
+	count := 0
+
+	return func() (item T, index int, ok bool, err error) {
+		item, ok, err = next()
+		index = count
+		if ok {
+			count++
+		}
+		return
+	}
+}
+
+// ElementError is the error reported by a fallible query when a callback fails.
+// Index is the zero-based index of the failing element within the source
+// collection of the pipeline, that is the collection passed to AsTry or read by
+// a fallible source such as FromLines, whatever the stages the element went
+// through. Elements emitted by TrySelectMany keep the index of the element they
+// were projected from, and groups emitted by TryGroupBy the index of their
+// first element.
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// AsTry converts a query to a fallible query, so it can be chained with
+// TrySelect, TryWhere and the other fallible query functions.
+func AsTry[T any](q Query[T]) TryQuery[T] {
+	return TryQuery[T]{
+		Iterate: func() TryIterator[T] {
+			next := q.Iterate()
+
+			return func() (item T, ok bool, err error) {
+				item, ok = next()
+				return
+			}
+		},
+	}
+}
+
+// TrySelect projects each element of a collection into a new form by invoking a
+// selector that may fail. The iteration stops at the first error, which is
+// wrapped in an ElementError holding the index of the failing element.
+func TrySelect[T, V any](selector func(T) (V, error)) func(q TryQuery[T]) TryQuery[V] {
+	return func(q TryQuery[T]) TryQuery[V] {
+		return newTryQuery(func() indexedTryIterator[V] {
+			next := q.iterateIndexed()
+			var failed error
+
+			return func() (item V, index int, ok bool, err error) {
+				if failed != nil {
+					return item, 0, false, failed
+				}
+
+				it, index, ok, err := next()
+				if !ok {
+					failed = err
+					return item, 0, false, err
+				}
+
+				if item, err = selector(it); err != nil {
+					failed = &ElementError{Index: index, Err: err}
+					return item, 0, false, failed
+				}
+
+				return item, index, true, nil
+			}
+		})
+	}
+
+}
+
+// TryWhere filters a collection of values based on a predicate that may fail.
+// The iteration stops at the first error, which is wrapped in an ElementError
+// holding the index of the failing element.
+func TryWhere[T any](predicate func(T) (bool, error)) func(q TryQuery[T]) TryQuery[T] {
+	return func(q TryQuery[T]) TryQuery[T] {
+		return newTryQuery(func() indexedTryIterator[T] {
+			next := q.iterateIndexed()
+			var failed error
+
+			return func() (item T, index int, ok bool, err error) {
+				if failed != nil {
+					return item, 0, false, failed
+				}
+
+				for item, index, ok, err = next(); ok; item, index, ok, err = next() {
+					match, perr := predicate(item)
+					if perr != nil {
+						failed = &ElementError{Index: index, Err: perr}
+						var r T
+						return r, 0, false, failed
+					}
+
+					if match {
+						return
+					}
+				}
+				failed = err
+
+				return
+			}
+		})
+	}
+
+}
+
+// TrySelectMany projects each element of a collection to a Query by invoking a
+// selector that may fail, iterates and flattens the resulting collection into
+// one collection. The iteration stops at the first error, which is wrapped in
+// an ElementError holding the index of the failing element.
+func TrySelectMany[T, V any](selector func(T) (Query[V], error)) func(q TryQuery[T]) TryQuery[V] {
+	return func(q TryQuery[T]) TryQuery[V] {
+		return newTryQuery(func() indexedTryIterator[V] {
+			outernext := q.iterateIndexed()
+			var innernext Iterator[V]
+			outerIndex := 0
+			var failed error
+
+			return func() (item V, index int, ok bool, err error) {
+				for failed == nil {
+					if innernext == nil {
+						outer, i, outerOk, outerErr := outernext()
+						if !outerOk {
+							failed = outerErr
+							return item, 0, false, failed
+						}
+
+						inner, serr := selector(outer)
+						if serr != nil {
+							failed = &ElementError{Index: i, Err: serr}
+							return item, 0, false, failed
+						}
+						outerIndex = i
+						innernext = inner.Iterate()
+					}
+
+					if item, ok = innernext(); ok {
+						return item, outerIndex, true, nil
+					}
+					innernext = nil
+				}
+
+				return item, 0, false, failed
+			}
+		})
+	}
+
+}
+
+// TryGroupBy groups the elements of a collection according to a key selector
+// function and projects the elements for each group by using an element
+// selector function. Both selectors may fail, in which case no group is emitted
+// and the error is wrapped in an ElementError holding the index of the failing
+// element.
+//
+// Groups are emitted in the order their key first appears in the collection.
+func TryGroupBy[T any, K comparable, V any](keySelector func(T) (K, error),
+	elementSelector func(T) (V, error)) func(q TryQuery[T]) TryQuery[Group[K, V]] {
+	return func(q TryQuery[T]) TryQuery[Group[K, V]] {
+		return newTryQuery(func() indexedTryIterator[Group[K, V]] {
+			next := q.iterateIndexed()
+			var keys []K
+			set := map[K][]V{}
+			firstIndex := map[K]int{}
+
+			var failed error
+			for item, index, ok, err := next(); ; item, index, ok, err = next() {
+				if !ok {
+					failed = err
+					break
+				}
+
+				key, kerr := keySelector(item)
+				if kerr != nil {
+					failed = &ElementError{Index: index, Err: kerr}
+					break
+				}
+				element, eerr := elementSelector(item)
+				if eerr != nil {
+					failed = &ElementError{Index: index, Err: eerr}
+					break
+				}
+
+				if _, has := set[key]; !has {
+					keys = append(keys, key)
+					firstIndex[key] = index
+				}
+				set[key] = append(set[key], element)
+			}
+
+			length := len(keys)
+			position := 0
+
+			return func() (item Group[K, V], index int, ok bool, err error) {
+				if failed != nil {
+					return item, 0, false, failed
+				}
+
+				ok = position < length
+				if ok {
+					key := keys[position]
+					item = Group[K, V]{key, set[key]}
+					index = firstIndex[key]
+					position++
+				}
+
+				return
+			}
+		})
+	}
+
+}
+
+// TryFirst returns the first element of a fallible collection, or the error
+// that occurred while evaluating it.
+func TryFirst[T any](q TryQuery[T]) (item T, ok bool, err error) {
+	releaseScoped(func(*releaseScope) {
+		item, ok, err = q.Iterate()()
+	})
+
+	return
+}
+
+// TryForEach performs the specified fallible action on each element of a
+// fallible collection. It stops at the first error, the errors returned by
+// action are wrapped in an ElementError holding the index of the element.
+func TryForEach[T any](action func(T) error) func(q TryQuery[T]) error {
+	return func(q TryQuery[T]) (err error) {
+		releaseScoped(func(s *releaseScope) {
+			next := q.iterateIndexed()
+
+			var (
+				item  T
+				index int
+				ok    bool
+			)
+			for item, index, ok, err = next(); ok; item, index, ok, err = next() {
+				s.paused = true
+				aerr := action(item)
+				s.paused = false
+				if aerr != nil {
+					err = &ElementError{Index: index, Err: aerr}
+					return
+				}
+			}
+		})
+
+		return
+	}
+
+}
+
+// TryToSlice iterates over a fallible collection and saves the results in a
+// slice. It returns nil and the first error that occurred, if any.
+func TryToSlice[T any](q TryQuery[T]) ([]T, error) {
+	r := []T{}
+	var err error
+	releaseScoped(func(*releaseScope) {
+		next := q.Iterate()
+
+		var (
+			item T
+			ok   bool
+		)
+		for item, ok, err = next(); ok; item, ok, err = next() {
+			r = append(r, item)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,160 @@
+package flinx
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTrySelect(t *testing.T) {
+	q := TrySelect(strconv.Atoi)(AsTry(FromSlice([]string{"1", "2", "3"})))
+	r, err := TryToSlice(q)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []int{1, 2, 3})
+
+	pulled := 0
+	source := Select(func(s string) string {
+		pulled++
+		return s
+	})(FromSlice([]string{"1", "x", "3"}))
+	r, err = TryToSlice(TrySelect(strconv.Atoi)(AsTry(source)))
+	assert.Assert(t, r == nil)
+	var elementErr *ElementError
+	assert.Assert(t, errors.As(err, &elementErr))
+	assert.Equal(t, elementErr.Index, 1)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, pulled, 2)
+}
+
+func TestElementErrorSourceIndex(t *testing.T) {
+	errThree := errors.New("three")
+	odd := func(i int) (bool, error) {
+		return i%2 == 1, nil
+	}
+	failOnThree := func(i int) (int, error) {
+		if i == 3 {
+			return 0, errThree
+		}
+		return i, nil
+	}
+
+	// 3 is the second element reaching TrySelect, but the fourth of the source.
+	_, err := TryToSlice(TrySelect(failOnThree)(TryWhere(odd)(AsTry(Range(0, 10)))))
+	var elementErr *ElementError
+	assert.Assert(t, errors.As(err, &elementErr))
+	assert.Equal(t, elementErr.Index, 3)
+	assert.ErrorIs(t, err, errThree)
+
+	err = TryForEach(func(i int) error {
+		_, err := failOnThree(i)
+		return err
+	})(TryWhere(odd)(AsTry(Range(0, 10))))
+	assert.Error(t, err, "element 3: three")
+
+	// Elements emitted by TrySelectMany keep the index of their source element.
+	repeat := func(i int) (Query[int], error) {
+		return Repeat(i, 2), nil
+	}
+	_, err = TryToSlice(TrySelect(failOnThree)(TrySelectMany(repeat)(AsTry(FromSlice([]int{1, 2, 3})))))
+	assert.Error(t, err, "element 2: three")
+
+	// Groups keep the index of their first element.
+	parity := func(i int) (int, error) {
+		return i % 2, nil
+	}
+	_, err = TryToSlice(TrySelect(func(g Group[int, int]) (int, error) {
+		return failOnThree(len(g.Group))
+	})(TryGroupBy(parity, func(i int) (int, error) { return i, nil })(AsTry(FromSlice([]int{2, 1, 3, 5, 4})))))
+	assert.Error(t, err, "element 1: three")
+}
+
+func TestTryWhere(t *testing.T) {
+	errOdd := errors.New("odd")
+	even := func(i int) (bool, error) {
+		if i == 7 {
+			return false, errOdd
+		}
+		return i%2 == 0, nil
+	}
+
+	r, err := TryToSlice(TryWhere(even)(AsTry(Range(1, 6))))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []int{2, 4, 6})
+
+	q := TryWhere(even)(AsTry(Range(1, 10)))
+	next := q.Iterate()
+	for _, want := range []int{2, 4, 6} {
+		item, ok, err := next()
+		assert.NilError(t, err)
+		assert.Assert(t, ok)
+		assert.Equal(t, item, want)
+	}
+	_, ok, err := next()
+	assert.Assert(t, !ok)
+	assert.Error(t, err, "element 6: odd")
+	_, _, again := next()
+	assert.Equal(t, again, err)
+}
+
+func TestTrySelectMany(t *testing.T) {
+	errEmpty := errors.New("empty")
+	selector := func(s string) (Query[rune], error) {
+		if s == "" {
+			return Query[rune]{}, errEmpty
+		}
+		return FromString(s), nil
+	}
+
+	r, err := TryToSlice(TrySelectMany(selector)(AsTry(FromSlice([]string{"ab", "c"}))))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []rune{'a', 'b', 'c'})
+
+	r, err = TryToSlice(TrySelectMany(selector)(AsTry(FromSlice([]string{"ab", "", "c"}))))
+	assert.Assert(t, r == nil)
+	assert.Error(t, err, "element 1: empty")
+}
+
+func TestTryGroupBy(t *testing.T) {
+	r, err := TryToSlice(TryGroupBy(strconv.Atoi, func(s string) (string, error) {
+		return s + "!", nil
+	})(AsTry(FromSlice([]string{"2", "1", "2"}))))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r, []Group[int, string]{{2, []string{"2!", "2!"}}, {1, []string{"1!"}}})
+
+	_, err = TryToSlice(TryGroupBy(strconv.Atoi, func(s string) (string, error) {
+		return s, nil
+	})(AsTry(FromSlice([]string{"2", "1", "a"}))))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorContains(t, err, "element 2")
+}
+
+func TestTryFirst(t *testing.T) {
+	r, ok, err := TryFirst(TrySelect(strconv.Atoi)(AsTry(FromSlice([]string{"5", "x"}))))
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, r, 5)
+
+	_, ok, err = TryFirst(TrySelect(strconv.Atoi)(AsTry(FromSlice([]string{"x"}))))
+	assert.Assert(t, !ok)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func TestTryForEach(t *testing.T) {
+	var r []int
+	err := TryForEach(func(i int) error {
+		if i > 2 {
+			return errors.New("too big")
+		}
+		r = append(r, i)
+		return nil
+	})(AsTry(Range(1, 5)))
+	assert.Error(t, err, "element 2: too big")
+	assert.DeepEqual(t, r, []int{1, 2})
+
+	err = TryForEach(func(i int) error {
+		return nil
+	})(TrySelect(strconv.Atoi)(AsTry(FromSlice([]string{"1", "x"}))))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
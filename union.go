@@ -1,6 +1,9 @@
 package flinx
 
-import "github.com/kom0055/go-flinx/hashset"
+import (
+	"github.com/kom0055/go-flinx/generics"
+	"github.com/kom0055/go-flinx/hashset"
+)
 
 // Union produces the set union of two collections.
 //
@@ -43,3 +46,40 @@ func Union[T comparable](q, q2 Query[T]) Query[T] {
 	}
 
 }
+
+// UnionUsing produces the set union of two collections, using comparer to find
+// equal elements, so it works for elements that are not comparable with ==.
+func UnionUsing[T any](comparer generics.EqualityComparer[T]) func(q, q2 Query[T]) Query[T] {
+	return func(q, q2 Query[T]) Query[T] {
+		return Query[T]{
+			Iterate: func() Iterator[T] {
+				next := q.Iterate()
+				next2 := q2.Iterate()
+
+				set := newHashTable(comparer)
+				use1 := true
+
+				return func() (item T, ok bool) {
+					if use1 {
+						for item, ok = next(); ok; item, ok = next() {
+							if set.Insert(item) {
+								return
+							}
+						}
+
+						use1 = false
+					}
+
+					for item, ok = next2(); ok; item, ok = next2() {
+						if set.Insert(item) {
+							return
+						}
+					}
+
+					return
+				}
+			},
+		}
+	}
+
+}
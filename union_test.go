@@ -1,6 +1,9 @@
 package flinx
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestUnion(t *testing.T) {
 	input1 := []int{1, 2, 3}
@@ -11,3 +14,13 @@ func TestUnion(t *testing.T) {
 		t.Errorf("From(%v).Union(%v)=%v expected %v", input1, input2, ToSlice(q), want)
 	}
 }
+
+func TestUnionUsing(t *testing.T) {
+	input1 := [][]int{{1}, {2}, {1}}
+	input2 := [][]int{{2}, {1, 2}, {3}}
+	want := [][]int{{1}, {2}, {1, 2}, {3}}
+
+	if q := UnionUsing(sliceComparer)(FromSlice(input1), FromSlice(input2)); !reflect.DeepEqual(ToSlice(q), want) {
+		t.Errorf("From(%v).UnionUsing(%v)=%v expected %v", input1, input2, ToSlice(q), want)
+	}
+}
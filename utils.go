@@ -1,7 +1,12 @@
 package flinx
 
 import (
+	"bytes"
 	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"golang.org/x/exp/constraints"
 
@@ -114,3 +119,144 @@ func PredicatesIndexed[T any](predicates ...func(int, T) bool) func(int, T) bool
 		return true
 	}
 }
+
+// releaser runs a cleanup function exactly once, either when release is called
+// explicitly, when the releaseScope it was created in is released, or when the
+// releaser becomes unreachable. It lets lazily iterated sources free resources
+// such as goroutines, pulled iterators or temporary files even if the consumer
+// abandons the iterator before it is exhausted.
+//
+// The cleanup function must not reference the releaser itself, otherwise the
+// finalizer will never run.
+type releaser struct {
+	once     sync.Once
+	fn       func()
+	released atomic.Bool
+}
+
+func newReleaser(fn func()) *releaser {
+	r := &releaser{fn: fn}
+	runtime.SetFinalizer(r, (*releaser).release)
+	if s := currentReleaseScope(); s != nil && !s.paused {
+		s.add(r)
+	}
+	return r
+}
+
+func (r *releaser) release() {
+	r.once.Do(func() {
+		r.fn()
+		r.released.Store(true)
+	})
+}
+
+var (
+	// releaseScopes maps the id of a goroutine to the innermost releaseScope
+	// it is running.
+	releaseScopes sync.Map
+	// runningScopes counts the scopes being run, so that newReleaser does not
+	// look up the goroutine id when there is none.
+	runningScopes atomic.Int64
+)
+
+// releaseScope collects the releasers created on a goroutine while it runs the
+// scope, whatever operator of the query created them, so that they can all be
+// released at once when the consumer is done with the query, for example when
+// a for range loop over All breaks.
+//
+// Scopes are run in a last in, first out order on a goroutine. A scope may be
+// run again later, on another goroutine too.
+type releaseScope struct {
+	goroutine uint64
+	parent    *releaseScope
+	// paused is set while the consumer handles an element, the releasers
+	// created meanwhile do not belong to the query.
+	paused    bool
+	releasers []*releaser
+}
+
+// run runs fn as the innermost scope of the current goroutine.
+func (s *releaseScope) run(fn func()) {
+	s.goroutine = goroutineID()
+	if parent, ok := releaseScopes.Load(s.goroutine); ok {
+		s.parent = parent.(*releaseScope)
+	}
+	releaseScopes.Store(s.goroutine, s)
+	runningScopes.Add(1)
+
+	defer func() {
+		if s.parent != nil {
+			releaseScopes.Store(s.goroutine, s.parent)
+		} else {
+			releaseScopes.Delete(s.goroutine)
+		}
+		s.parent = nil
+		runningScopes.Add(-1)
+	}()
+
+	fn()
+}
+
+func (s *releaseScope) add(r *releaser) {
+	if len(s.releasers) == cap(s.releasers) {
+		// Forget the releasers already released, such as those of the
+		// exhausted inner queries of SelectMany, so that long iterations do
+		// not accumulate them.
+		kept := s.releasers[:0]
+		for _, r := range s.releasers {
+			if !r.released.Load() {
+				kept = append(kept, r)
+			}
+		}
+		for i := len(kept); i < len(s.releasers); i++ {
+			s.releasers[i] = nil
+		}
+		if len(kept) > cap(s.releasers)/2 {
+			kept = append(make([]*releaser, 0, 2*cap(s.releasers)), kept...)
+		}
+		s.releasers = kept
+	}
+	s.releasers = append(s.releasers, r)
+}
+
+// release releases the collected releasers, the most recent one first.
+func (s *releaseScope) release() {
+	for i := len(s.releasers) - 1; i >= 0; i-- {
+		s.releasers[i].release()
+	}
+	s.releasers = nil
+}
+
+// releaseScoped runs fn in a new releaseScope, released once fn returns. The
+// functions consuming a query run in it, so that stopping early does not leave
+// the resources held by the query to the garbage collector.
+func releaseScoped(fn func(s *releaseScope)) {
+	s := &releaseScope{}
+	defer s.release()
+	s.run(func() {
+		fn(s)
+	})
+}
+
+func currentReleaseScope() *releaseScope {
+	if runningScopes.Load() == 0 {
+		return nil
+	}
+	if s, ok := releaseScopes.Load(goroutineID()); ok {
+		return s.(*releaseScope)
+	}
+	return nil
+}
+
+// goroutineID returns the id of the current goroutine, parsed from the header
+// of its stack trace: "goroutine 42 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}